@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"kabsa/internal/config"
+	"kabsa/internal/kafka"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const dlqUsage = `usage: kabsa-api dlq <command>
+
+commands:
+  list <topic> [N]                     show the newest N dead letters of <topic> (default 20)
+  show <topic> <partition> <offset>    print one dead letter with its headers and payload
+  replay <topic> <partition> <offset>  publish a dead letter back to its original topic
+
+<topic> is the full dead-letter topic name, e.g. kabsa_users.dlq.
+`
+
+// runDLQ implements the dlq subcommand and returns the exit code. It is a
+// CLI rather than an endpoint because the API has no authentication and
+// replaying messages changes state in every consumer.
+func runDLQ(_ context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) < 2 {
+		_, _ = fmt.Fprint(stderr, dlqUsage)
+		return 2
+	}
+
+	cmd, topic, rest := args[0], args[1], args[2:]
+	var (
+		limit     = 20
+		partition int32
+		offset    int64
+	)
+	switch {
+	case cmd == "list" && len(rest) <= 1:
+		if len(rest) == 1 {
+			n, err := strconv.Atoi(rest[0])
+			if err != nil || n < 1 {
+				_, _ = fmt.Fprintf(stderr, "list: invalid count %q\n", rest[0])
+				return 2
+			}
+			limit = n
+		}
+	case (cmd == "show" || cmd == "replay") && len(rest) == 2:
+		p, err := strconv.ParseInt(rest[0], 10, 32)
+		if err != nil || p < 0 {
+			_, _ = fmt.Fprintf(stderr, "%s: invalid partition %q\n", cmd, rest[0])
+			return 2
+		}
+		o, err := strconv.ParseInt(rest[1], 10, 64)
+		if err != nil || o < 0 {
+			_, _ = fmt.Fprintf(stderr, "%s: invalid offset %q\n", cmd, rest[1])
+			return 2
+		}
+		partition, offset = int32(p), o
+	default:
+		_, _ = fmt.Fprint(stderr, dlqUsage)
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to load config: %v\n", err)
+		return 1
+	}
+	admin, err := kafka.NewDLQAdmin(cfg.Kafka)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to init kafka: %v\n", err)
+		return 1
+	}
+	defer func() { _ = admin.Close() }()
+
+	switch cmd {
+	case "list":
+		letters, err := admin.List(topic, limit)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "dlq: %v\n", err)
+			return 1
+		}
+		w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "PARTITION\tOFFSET\tFAILED AT\tATTEMPTS\tHANDLER\tREASON")
+		for _, dl := range letters {
+			_, _ = fmt.Fprintf(w, "%d\t%d\t%s\t%d\t%s\t%s\n",
+				dl.Partition, dl.Offset, dl.FailedAt.Format(time.RFC3339), dl.Attempts, dl.Handler, dl.Reason)
+		}
+		_ = w.Flush()
+
+	case "show":
+		dl, err := admin.Get(topic, partition, offset)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "dlq: %v\n", err)
+			return 1
+		}
+		printDeadLetter(stdout, dl)
+
+	case "replay":
+		target, err := admin.Replay(topic, partition, offset)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "dlq: %v\n", err)
+			return 1
+		}
+		_, _ = fmt.Fprintf(stdout, "replayed %s/%d@%d to %s\n", topic, partition, offset, target)
+	}
+	return 0
+}
+
+func printDeadLetter(w io.Writer, dl kafka.DeadLetter) {
+	_, _ = fmt.Fprintf(w, "topic:          %s/%d@%d\n", dl.Topic, dl.Partition, dl.Offset)
+	_, _ = fmt.Fprintf(w, "original topic: %s\n", dl.OriginalTopic)
+	_, _ = fmt.Fprintf(w, "handler:        %s\n", dl.Handler)
+	_, _ = fmt.Fprintf(w, "failed at:      %s\n", dl.FailedAt.Format(time.RFC3339Nano))
+	_, _ = fmt.Fprintf(w, "attempts:       %d\n", dl.Attempts)
+	_, _ = fmt.Fprintf(w, "reason:         %s\n", dl.Reason)
+	_, _ = fmt.Fprintf(w, "key:            %s\n", dl.Key)
+	_, _ = fmt.Fprintln(w, "headers:")
+	keys := make([]string, 0, len(dl.Headers))
+	for k := range dl.Headers {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		_, _ = fmt.Fprintf(w, "  %s: %s\n", k, dl.Headers[k])
+	}
+	_, _ = fmt.Fprintf(w, "payload:\n%s\n", dl.Payload)
+}
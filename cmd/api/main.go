@@ -18,19 +18,26 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	_ "kabsa/docs"
 	"kabsa/internal/app/user"
 	"kabsa/internal/cache"
 	"kabsa/internal/config"
 	"kabsa/internal/db"
+	"kabsa/internal/db/migrate"
 	"kabsa/internal/db/repository"
 	"kabsa/internal/http/handlers/health"
 	userhandler "kabsa/internal/http/handlers/user"
 	"kabsa/internal/http/router"
+	"kabsa/internal/inbox"
 	"kabsa/internal/kafka"
 	"kabsa/internal/logging"
+	"kabsa/internal/outbox"
+	"kabsa/internal/pagination"
 	"kabsa/internal/telemetry"
+	"kabsa/migrations"
 	"log"
 	"net/http"
 	"os"
@@ -48,6 +55,26 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	if len(os.Args) > 1 {
+		var run func(context.Context, []string, io.Writer, io.Writer) int
+		switch os.Args[1] {
+		case "migrate":
+			run = runMigrate
+		case "seed":
+			run = runSeed
+		case "dlq":
+			run = runDLQ
+		}
+		if run != nil {
+			code := run(ctx, os.Args[2:], os.Stdout, os.Stderr)
+			stop()
+			os.Exit(code)
+		}
+	}
+
+	migrateOnStart := flag.Bool("migrate-on-start", false, "apply pending database migrations before serving")
+	flag.Parse()
+
 	// 1) Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -90,6 +117,13 @@ func main() {
 		_ = dbClient.Close()
 	}(dbClient)
 
+	if *migrateOnStart {
+		if err := migrateUp(ctx, dbClient, logger); err != nil {
+			logger.Error("failed to migrate database", "error", err)
+			os.Exit(1)
+		}
+	}
+
 	// 5) Initialize Redis
 	redisClient, err := cache.NewRedisClient(ctx, cfg.Redis, logger)
 	if err != nil {
@@ -118,27 +152,94 @@ func main() {
 		logger.Error("failed to init kafka router", "error", err)
 		os.Exit(1)
 	}
+	defer func() {
+		if err := kafkaRouter.Close(context.Background()); err != nil {
+			logger.Error("failed to close kafka router", "error", err)
+		}
+	}()
 
 	// 8) Construct repositories & services
+	cursorCodec, err := pagination.NewCursorCodec(cfg.Pagination.CursorSecret)
+	if err != nil {
+		logger.Error("failed to init cursor codec", "error", err)
+		os.Exit(1)
+	}
+	if cfg.Pagination.CursorSecret == "" {
+		logger.Info("PAGINATION_CURSOR_SECRET not set, list cursors are only valid for this process")
+	}
+
+	// Events go through the outbox so they commit with the change; the relay
+	// forwards them to the real bus. Without Kafka there is nothing to relay.
+	var eventBus kafka.Bus = bus
+	var outboxRelay *outbox.Relay
+	var consumerInbox *inbox.Inbox
+	if cfg.Kafka.Enabled {
+		eventBus = outbox.NewBus(dbClient)
+		outboxRelay = outbox.NewRelay(dbClient, bus, outbox.RelayOptions{
+			PollInterval: cfg.Outbox.PollInterval,
+			BatchSize:    cfg.Outbox.BatchSize,
+			MaxBackoff:   cfg.Outbox.MaxBackoff,
+			Retention:    cfg.Outbox.Retention,
+		}, logger)
+		// Consumers skip messages the group has processed before.
+		consumerInbox = inbox.New(dbClient, cfg.Kafka.GroupID, cfg.Inbox.Retention, logger)
+	}
+
 	userRepo := repository.NewUserRepository(dbClient, logger)
 	userCache := cache.NewUserCache(redisClient)
-	userEvents := kafka.NewUserEvents(bus, cfg.Kafka, logger)
+	userEvents := kafka.NewUserEvents(eventBus, cfg.Kafka, logger)
+	auditRepo := repository.NewAuditRepository(dbClient, logger)
 
 	userService := user.NewService(
 		userRepo,
 		userCache,
 		dbClient,   // db.Transactor
 		userEvents, // app/user.Events
+		auditRepo,  // audit.Repository
+		cursorCodec,
+		logger)
+
+	// Consumers: each module registers its handlers on a dispatcher per topic.
+	userDispatcher := kafka.NewDispatcher(kafka.SkipUnknown, logger)
+	kafka.RegisterUserCacheEviction(userDispatcher, userCache)
+	if consumerInbox != nil {
+		userDispatcher.Deduplicate(consumerInbox)
+	}
+	kafkaRouter.Handle("user-events-handler", cfg.Kafka.TopicPrefix+"users", userDispatcher)
+
+	userPurge := user.NewPurgeJob(
+		userRepo,
+		userCache,
+		dbClient,
+		userEvents,
+		auditRepo,
+		cfg.Users.SoftDeleteRetention,
+		cfg.Users.PurgeInterval,
 		logger)
 
 	// 8) HTTP handlers
 	healthHandler := health.NewHandler(dbClient, redisClient)
-	userHandler := userhandler.NewHandler(userService, logger)
+	userHandler := userhandler.NewHandler(userService, userhandler.Options{
+		RequireIfMatch: cfg.HTTP.RequireIfMatch,
+		ExposeDeleted:  cfg.HTTP.ExposeDeleted,
+		MaxBatchSize:   cfg.HTTP.MaxBatchSize,
+		MaxImportBytes: cfg.HTTP.MaxImportBytes,
+	}, logger)
 
 	// 9) HTTP router
+	trustedProxies, err := router.ParseTrustedProxies(cfg.HTTP.TrustedProxies)
+	if err != nil {
+		log.Fatalf("invalid HTTP_TRUSTED_PROXIES: %v", err)
+	}
 	httpRouter := router.NewRouter(
 		logger,
 		cfg.Observability.ServiceName,
+		router.Options{
+			ActorHeader:    cfg.HTTP.ActorHeader,
+			TrustedProxies: trustedProxies,
+			RequestTimeout: cfg.HTTP.RequestTimeout,
+			StreamTimeout:  cfg.HTTP.StreamTimeout,
+		},
 		healthHandler,
 		userHandler,
 	)
@@ -152,8 +253,8 @@ func main() {
 		),
 	}
 
-	// 11) Start concurrent processes (HTTP server, Kafka router)
-	errCh := make(chan error, 2)
+	// 11) Start concurrent processes (HTTP server, Kafka router, outbox relay, inbox pruning, purge job)
+	errCh := make(chan error, 5)
 
 	go func() {
 		logger.Info("http server starting",
@@ -172,6 +273,36 @@ func main() {
 		}
 	}()
 
+	if outboxRelay != nil {
+		go func() {
+			logger.Info("outbox relay starting")
+			if err := outboxRelay.Run(ctx); err != nil {
+				errCh <- err
+			}
+		}()
+	}
+
+	if consumerInbox != nil {
+		go func() {
+			logger.Info("inbox pruning starting", "retention", cfg.Inbox.Retention.String())
+			if err := consumerInbox.Run(ctx); err != nil {
+				errCh <- err
+			}
+		}()
+	}
+
+	if cfg.Users.SoftDeleteRetention > 0 {
+		go func() {
+			logger.Info("user purge job starting",
+				"retention", cfg.Users.SoftDeleteRetention.String(),
+				"interval", cfg.Users.PurgeInterval.String(),
+			)
+			if err := userPurge.Run(ctx); err != nil {
+				errCh <- err
+			}
+		}()
+	}
+
 	// 12) Wait for shutdown signal or an error
 	select {
 	case <-ctx.Done():
@@ -192,3 +323,27 @@ func main() {
 
 	logger.Info("service stopped")
 }
+
+// migrateUp applies pending migrations; the migrator's advisory lock makes
+// it safe for every pod to do this on start. Drift is only logged so a
+// hand-applied hotfix does not keep the service down.
+func migrateUp(ctx context.Context, dbClient *db.Client, logger logging.Logger) error {
+	migrator, err := migrate.New(dbClient, migrations.FS, logger)
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Info("database migrated", "applied", len(applied))
+
+	drift, err := migrator.Drift(ctx)
+	if err != nil {
+		return err
+	}
+	for _, d := range drift {
+		logger.Error("database schema drift", "object", d.Object, "problem", d.Problem)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"kabsa/internal/config"
+	"kabsa/internal/db"
+	"kabsa/internal/db/migrate"
+	"kabsa/internal/logging"
+	"kabsa/migrations"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = `usage: kabsa-api migrate <command>
+
+commands:
+  up                 apply all pending migrations
+  down [N]           revert the last N migrations (default 1)
+  to <version>       apply or revert until <version> is the latest applied (0 reverts all)
+  status             list migrations and report drift from the Ent schema
+  baseline <version> mark migrations up to <version> as applied without running them
+`
+
+// runMigrate implements the migrate subcommand and returns the exit code.
+func runMigrate(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, migrateUsage)
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to load config: %v\n", err)
+		return 1
+	}
+	logger := logging.New(cfg.Observability.ServiceName, cfg.Observability.ServiceEnv)
+
+	dbClient, err := db.NewClient(ctx, cfg.Postgres, logger)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to init database: %v\n", err)
+		return 1
+	}
+	defer func() { _ = dbClient.Close() }()
+
+	migrator, err := migrate.New(dbClient, migrations.FS, logger)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to load migrations: %v\n", err)
+		return 1
+	}
+
+	report := func(verb string, done []migrate.Migration, err error) int {
+		for _, m := range done {
+			_, _ = fmt.Fprintf(stdout, "%s %d_%s\n", verb, m.Version, m.Name)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "migrate: %v\n", err)
+			return 1
+		}
+		if len(done) == 0 {
+			_, _ = fmt.Fprintln(stdout, "nothing to do")
+		}
+		return 0
+	}
+
+	cmd, rest := args[0], args[1:]
+	switch {
+	case cmd == "up" && len(rest) == 0:
+		done, err := migrator.Up(ctx)
+		return report("applied", done, err)
+
+	case cmd == "down" && len(rest) <= 1:
+		steps := 1
+		if len(rest) == 1 {
+			if steps, err = strconv.Atoi(rest[0]); err != nil || steps < 1 {
+				_, _ = fmt.Fprintf(stderr, "down: invalid step count %q\n", rest[0])
+				return 2
+			}
+		}
+		done, err := migrator.Down(ctx, steps)
+		return report("reverted", done, err)
+
+	case cmd == "to" && len(rest) == 1:
+		version, err := strconv.ParseInt(rest[0], 10, 64)
+		if err != nil || version < 0 {
+			_, _ = fmt.Fprintf(stderr, "to: invalid version %q\n", rest[0])
+			return 2
+		}
+		done, err := migrator.To(ctx, version)
+		return report("migrated", done, err)
+
+	case cmd == "baseline" && len(rest) == 1:
+		version, err := strconv.ParseInt(rest[0], 10, 64)
+		if err != nil || version < 1 {
+			_, _ = fmt.Fprintf(stderr, "baseline: invalid version %q\n", rest[0])
+			return 2
+		}
+		done, err := migrator.Baseline(ctx, version)
+		return report("marked", done, err)
+
+	case cmd == "status" && len(rest) == 0:
+		return migrateStatus(ctx, migrator, stdout, stderr)
+	}
+
+	_, _ = fmt.Fprint(stderr, migrateUsage)
+	return 2
+}
+
+// migrateStatus prints every migration and any drift; it exits non-zero when
+// migrations are pending or the schema has drifted, so CI can gate on it.
+func migrateStatus(ctx context.Context, migrator *migrate.Migrator, stdout, stderr io.Writer) int {
+	list, err := migrator.Status(ctx)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "migrate: %v\n", err)
+		return 1
+	}
+	drift, err := migrator.Drift(ctx)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "migrate: %v\n", err)
+		return 1
+	}
+
+	pending := 0
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+	for _, s := range list {
+		applied := "pending"
+		switch {
+		case s.AppliedAt == nil:
+			pending++
+		case s.Modified:
+			applied = s.AppliedAt.Format(time.RFC3339) + " (modified)"
+		default:
+			applied = s.AppliedAt.Format(time.RFC3339)
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, applied)
+	}
+	_ = w.Flush()
+
+	if len(drift) > 0 {
+		_, _ = fmt.Fprintln(stdout, "\ndrift:")
+		for _, d := range drift {
+			_, _ = fmt.Fprintf(stdout, "  %s\n", d)
+		}
+	}
+	if pending > 0 || len(drift) > 0 {
+		return 1
+	}
+	return 0
+}
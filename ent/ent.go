@@ -6,6 +6,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"kabsa/ent/auditentry"
+	"kabsa/ent/inboxmessage"
+	"kabsa/ent/outboxmessage"
 	"kabsa/ent/user"
 	"reflect"
 	"sync"
@@ -73,7 +76,10 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			user.Table: user.ValidColumn,
+			auditentry.Table:    auditentry.ValidColumn,
+			inboxmessage.Table:  inboxmessage.ValidColumn,
+			outboxmessage.Table: outboxmessage.ValidColumn,
+			user.Table:          user.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
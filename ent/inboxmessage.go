@@ -0,0 +1,128 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"kabsa/ent/inboxmessage"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// InboxMessage is the model entity for the InboxMessage schema.
+type InboxMessage struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// ConsumerGroup holds the value of the "consumer_group" field.
+	ConsumerGroup string `json:"consumer_group,omitempty"`
+	// Envelope.MessageID
+	MessageID string `json:"message_id,omitempty"`
+	// ProcessedAt holds the value of the "processed_at" field.
+	ProcessedAt  time.Time `json:"processed_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*InboxMessage) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case inboxmessage.FieldID:
+			values[i] = new(sql.NullInt64)
+		case inboxmessage.FieldConsumerGroup, inboxmessage.FieldMessageID:
+			values[i] = new(sql.NullString)
+		case inboxmessage.FieldProcessedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the InboxMessage fields.
+func (_m *InboxMessage) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case inboxmessage.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int64(value.Int64)
+		case inboxmessage.FieldConsumerGroup:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field consumer_group", values[i])
+			} else if value.Valid {
+				_m.ConsumerGroup = value.String
+			}
+		case inboxmessage.FieldMessageID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field message_id", values[i])
+			} else if value.Valid {
+				_m.MessageID = value.String
+			}
+		case inboxmessage.FieldProcessedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field processed_at", values[i])
+			} else if value.Valid {
+				_m.ProcessedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the InboxMessage.
+// This includes values selected through modifiers, order, etc.
+func (_m *InboxMessage) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this InboxMessage.
+// Note that you need to call InboxMessage.Unwrap() before calling this method if this InboxMessage
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *InboxMessage) Update() *InboxMessageUpdateOne {
+	return NewInboxMessageClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the InboxMessage entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *InboxMessage) Unwrap() *InboxMessage {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: InboxMessage is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *InboxMessage) String() string {
+	var builder strings.Builder
+	builder.WriteString("InboxMessage(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("consumer_group=")
+	builder.WriteString(_m.ConsumerGroup)
+	builder.WriteString(", ")
+	builder.WriteString("message_id=")
+	builder.WriteString(_m.MessageID)
+	builder.WriteString(", ")
+	builder.WriteString("processed_at=")
+	builder.WriteString(_m.ProcessedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// InboxMessages is a parsable slice of InboxMessage.
+type InboxMessages []*InboxMessage
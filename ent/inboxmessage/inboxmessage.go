@@ -0,0 +1,74 @@
+// Code generated by ent, DO NOT EDIT.
+
+package inboxmessage
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the inboxmessage type in the database.
+	Label = "inbox_message"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldConsumerGroup holds the string denoting the consumer_group field in the database.
+	FieldConsumerGroup = "consumer_group"
+	// FieldMessageID holds the string denoting the message_id field in the database.
+	FieldMessageID = "message_id"
+	// FieldProcessedAt holds the string denoting the processed_at field in the database.
+	FieldProcessedAt = "processed_at"
+	// Table holds the table name of the inboxmessage in the database.
+	Table = "inbox"
+)
+
+// Columns holds all SQL columns for inboxmessage fields.
+var Columns = []string{
+	FieldID,
+	FieldConsumerGroup,
+	FieldMessageID,
+	FieldProcessedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// ConsumerGroupValidator is a validator for the "consumer_group" field. It is called by the builders before save.
+	ConsumerGroupValidator func(string) error
+	// MessageIDValidator is a validator for the "message_id" field. It is called by the builders before save.
+	MessageIDValidator func(string) error
+	// DefaultProcessedAt holds the default value on creation for the "processed_at" field.
+	DefaultProcessedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the InboxMessage queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByConsumerGroup orders the results by the consumer_group field.
+func ByConsumerGroup(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldConsumerGroup, opts...).ToFunc()
+}
+
+// ByMessageID orders the results by the message_id field.
+func ByMessageID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMessageID, opts...).ToFunc()
+}
+
+// ByProcessedAt orders the results by the processed_at field.
+func ByProcessedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldProcessedAt, opts...).ToFunc()
+}
@@ -0,0 +1,255 @@
+// Code generated by ent, DO NOT EDIT.
+
+package inboxmessage
+
+import (
+	"kabsa/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldLTE(FieldID, id))
+}
+
+// ConsumerGroup applies equality check predicate on the "consumer_group" field. It's identical to ConsumerGroupEQ.
+func ConsumerGroup(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldEQ(FieldConsumerGroup, v))
+}
+
+// MessageID applies equality check predicate on the "message_id" field. It's identical to MessageIDEQ.
+func MessageID(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldEQ(FieldMessageID, v))
+}
+
+// ProcessedAt applies equality check predicate on the "processed_at" field. It's identical to ProcessedAtEQ.
+func ProcessedAt(v time.Time) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldEQ(FieldProcessedAt, v))
+}
+
+// ConsumerGroupEQ applies the EQ predicate on the "consumer_group" field.
+func ConsumerGroupEQ(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldEQ(FieldConsumerGroup, v))
+}
+
+// ConsumerGroupNEQ applies the NEQ predicate on the "consumer_group" field.
+func ConsumerGroupNEQ(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldNEQ(FieldConsumerGroup, v))
+}
+
+// ConsumerGroupIn applies the In predicate on the "consumer_group" field.
+func ConsumerGroupIn(vs ...string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldIn(FieldConsumerGroup, vs...))
+}
+
+// ConsumerGroupNotIn applies the NotIn predicate on the "consumer_group" field.
+func ConsumerGroupNotIn(vs ...string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldNotIn(FieldConsumerGroup, vs...))
+}
+
+// ConsumerGroupGT applies the GT predicate on the "consumer_group" field.
+func ConsumerGroupGT(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldGT(FieldConsumerGroup, v))
+}
+
+// ConsumerGroupGTE applies the GTE predicate on the "consumer_group" field.
+func ConsumerGroupGTE(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldGTE(FieldConsumerGroup, v))
+}
+
+// ConsumerGroupLT applies the LT predicate on the "consumer_group" field.
+func ConsumerGroupLT(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldLT(FieldConsumerGroup, v))
+}
+
+// ConsumerGroupLTE applies the LTE predicate on the "consumer_group" field.
+func ConsumerGroupLTE(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldLTE(FieldConsumerGroup, v))
+}
+
+// ConsumerGroupContains applies the Contains predicate on the "consumer_group" field.
+func ConsumerGroupContains(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldContains(FieldConsumerGroup, v))
+}
+
+// ConsumerGroupHasPrefix applies the HasPrefix predicate on the "consumer_group" field.
+func ConsumerGroupHasPrefix(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldHasPrefix(FieldConsumerGroup, v))
+}
+
+// ConsumerGroupHasSuffix applies the HasSuffix predicate on the "consumer_group" field.
+func ConsumerGroupHasSuffix(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldHasSuffix(FieldConsumerGroup, v))
+}
+
+// ConsumerGroupEqualFold applies the EqualFold predicate on the "consumer_group" field.
+func ConsumerGroupEqualFold(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldEqualFold(FieldConsumerGroup, v))
+}
+
+// ConsumerGroupContainsFold applies the ContainsFold predicate on the "consumer_group" field.
+func ConsumerGroupContainsFold(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldContainsFold(FieldConsumerGroup, v))
+}
+
+// MessageIDEQ applies the EQ predicate on the "message_id" field.
+func MessageIDEQ(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldEQ(FieldMessageID, v))
+}
+
+// MessageIDNEQ applies the NEQ predicate on the "message_id" field.
+func MessageIDNEQ(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldNEQ(FieldMessageID, v))
+}
+
+// MessageIDIn applies the In predicate on the "message_id" field.
+func MessageIDIn(vs ...string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldIn(FieldMessageID, vs...))
+}
+
+// MessageIDNotIn applies the NotIn predicate on the "message_id" field.
+func MessageIDNotIn(vs ...string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldNotIn(FieldMessageID, vs...))
+}
+
+// MessageIDGT applies the GT predicate on the "message_id" field.
+func MessageIDGT(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldGT(FieldMessageID, v))
+}
+
+// MessageIDGTE applies the GTE predicate on the "message_id" field.
+func MessageIDGTE(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldGTE(FieldMessageID, v))
+}
+
+// MessageIDLT applies the LT predicate on the "message_id" field.
+func MessageIDLT(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldLT(FieldMessageID, v))
+}
+
+// MessageIDLTE applies the LTE predicate on the "message_id" field.
+func MessageIDLTE(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldLTE(FieldMessageID, v))
+}
+
+// MessageIDContains applies the Contains predicate on the "message_id" field.
+func MessageIDContains(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldContains(FieldMessageID, v))
+}
+
+// MessageIDHasPrefix applies the HasPrefix predicate on the "message_id" field.
+func MessageIDHasPrefix(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldHasPrefix(FieldMessageID, v))
+}
+
+// MessageIDHasSuffix applies the HasSuffix predicate on the "message_id" field.
+func MessageIDHasSuffix(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldHasSuffix(FieldMessageID, v))
+}
+
+// MessageIDEqualFold applies the EqualFold predicate on the "message_id" field.
+func MessageIDEqualFold(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldEqualFold(FieldMessageID, v))
+}
+
+// MessageIDContainsFold applies the ContainsFold predicate on the "message_id" field.
+func MessageIDContainsFold(v string) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldContainsFold(FieldMessageID, v))
+}
+
+// ProcessedAtEQ applies the EQ predicate on the "processed_at" field.
+func ProcessedAtEQ(v time.Time) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldEQ(FieldProcessedAt, v))
+}
+
+// ProcessedAtNEQ applies the NEQ predicate on the "processed_at" field.
+func ProcessedAtNEQ(v time.Time) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldNEQ(FieldProcessedAt, v))
+}
+
+// ProcessedAtIn applies the In predicate on the "processed_at" field.
+func ProcessedAtIn(vs ...time.Time) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldIn(FieldProcessedAt, vs...))
+}
+
+// ProcessedAtNotIn applies the NotIn predicate on the "processed_at" field.
+func ProcessedAtNotIn(vs ...time.Time) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldNotIn(FieldProcessedAt, vs...))
+}
+
+// ProcessedAtGT applies the GT predicate on the "processed_at" field.
+func ProcessedAtGT(v time.Time) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldGT(FieldProcessedAt, v))
+}
+
+// ProcessedAtGTE applies the GTE predicate on the "processed_at" field.
+func ProcessedAtGTE(v time.Time) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldGTE(FieldProcessedAt, v))
+}
+
+// ProcessedAtLT applies the LT predicate on the "processed_at" field.
+func ProcessedAtLT(v time.Time) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldLT(FieldProcessedAt, v))
+}
+
+// ProcessedAtLTE applies the LTE predicate on the "processed_at" field.
+func ProcessedAtLTE(v time.Time) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.FieldLTE(FieldProcessedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.InboxMessage) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.InboxMessage) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.InboxMessage) predicate.InboxMessage {
+	return predicate.InboxMessage(sql.NotPredicates(p))
+}
@@ -0,0 +1,250 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kabsa/ent/inboxmessage"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// InboxMessageCreate is the builder for creating a InboxMessage entity.
+type InboxMessageCreate struct {
+	config
+	mutation *InboxMessageMutation
+	hooks    []Hook
+}
+
+// SetConsumerGroup sets the "consumer_group" field.
+func (_c *InboxMessageCreate) SetConsumerGroup(v string) *InboxMessageCreate {
+	_c.mutation.SetConsumerGroup(v)
+	return _c
+}
+
+// SetMessageID sets the "message_id" field.
+func (_c *InboxMessageCreate) SetMessageID(v string) *InboxMessageCreate {
+	_c.mutation.SetMessageID(v)
+	return _c
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (_c *InboxMessageCreate) SetProcessedAt(v time.Time) *InboxMessageCreate {
+	_c.mutation.SetProcessedAt(v)
+	return _c
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (_c *InboxMessageCreate) SetNillableProcessedAt(v *time.Time) *InboxMessageCreate {
+	if v != nil {
+		_c.SetProcessedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *InboxMessageCreate) SetID(v int64) *InboxMessageCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// Mutation returns the InboxMessageMutation object of the builder.
+func (_c *InboxMessageCreate) Mutation() *InboxMessageMutation {
+	return _c.mutation
+}
+
+// Save creates the InboxMessage in the database.
+func (_c *InboxMessageCreate) Save(ctx context.Context) (*InboxMessage, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *InboxMessageCreate) SaveX(ctx context.Context) *InboxMessage {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *InboxMessageCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *InboxMessageCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *InboxMessageCreate) defaults() {
+	if _, ok := _c.mutation.ProcessedAt(); !ok {
+		v := inboxmessage.DefaultProcessedAt()
+		_c.mutation.SetProcessedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *InboxMessageCreate) check() error {
+	if _, ok := _c.mutation.ConsumerGroup(); !ok {
+		return &ValidationError{Name: "consumer_group", err: errors.New(`ent: missing required field "InboxMessage.consumer_group"`)}
+	}
+	if v, ok := _c.mutation.ConsumerGroup(); ok {
+		if err := inboxmessage.ConsumerGroupValidator(v); err != nil {
+			return &ValidationError{Name: "consumer_group", err: fmt.Errorf(`ent: validator failed for field "InboxMessage.consumer_group": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.MessageID(); !ok {
+		return &ValidationError{Name: "message_id", err: errors.New(`ent: missing required field "InboxMessage.message_id"`)}
+	}
+	if v, ok := _c.mutation.MessageID(); ok {
+		if err := inboxmessage.MessageIDValidator(v); err != nil {
+			return &ValidationError{Name: "message_id", err: fmt.Errorf(`ent: validator failed for field "InboxMessage.message_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ProcessedAt(); !ok {
+		return &ValidationError{Name: "processed_at", err: errors.New(`ent: missing required field "InboxMessage.processed_at"`)}
+	}
+	return nil
+}
+
+func (_c *InboxMessageCreate) sqlSave(ctx context.Context) (*InboxMessage, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *InboxMessageCreate) createSpec() (*InboxMessage, *sqlgraph.CreateSpec) {
+	var (
+		_node = &InboxMessage{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(inboxmessage.Table, sqlgraph.NewFieldSpec(inboxmessage.FieldID, field.TypeInt64))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.ConsumerGroup(); ok {
+		_spec.SetField(inboxmessage.FieldConsumerGroup, field.TypeString, value)
+		_node.ConsumerGroup = value
+	}
+	if value, ok := _c.mutation.MessageID(); ok {
+		_spec.SetField(inboxmessage.FieldMessageID, field.TypeString, value)
+		_node.MessageID = value
+	}
+	if value, ok := _c.mutation.ProcessedAt(); ok {
+		_spec.SetField(inboxmessage.FieldProcessedAt, field.TypeTime, value)
+		_node.ProcessedAt = value
+	}
+	return _node, _spec
+}
+
+// InboxMessageCreateBulk is the builder for creating many InboxMessage entities in bulk.
+type InboxMessageCreateBulk struct {
+	config
+	err      error
+	builders []*InboxMessageCreate
+}
+
+// Save creates the InboxMessage entities in the database.
+func (_c *InboxMessageCreateBulk) Save(ctx context.Context) ([]*InboxMessage, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*InboxMessage, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*InboxMessageMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *InboxMessageCreateBulk) SaveX(ctx context.Context) []*InboxMessage {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *InboxMessageCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *InboxMessageCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -3,18 +3,107 @@
 package migrate
 
 import (
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/schema/field"
 )
 
 var (
+	// AuditLogColumns holds the columns for the "audit_log" table.
+	AuditLogColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "actor", Type: field.TypeString},
+		{Name: "action", Type: field.TypeString},
+		{Name: "entity_type", Type: field.TypeString},
+		{Name: "entity_id", Type: field.TypeInt64},
+		{Name: "changes", Type: field.TypeJSON},
+		{Name: "request_id", Type: field.TypeString, Nullable: true},
+		{Name: "trace_id", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// AuditLogTable holds the schema information for the "audit_log" table.
+	AuditLogTable = &schema.Table{
+		Name:       "audit_log",
+		Columns:    AuditLogColumns,
+		PrimaryKey: []*schema.Column{AuditLogColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "audit_log_entity_idx",
+				Unique:  false,
+				Columns: []*schema.Column{AuditLogColumns[3], AuditLogColumns[4], AuditLogColumns[0]},
+			},
+		},
+	}
+	// InboxColumns holds the columns for the "inbox" table.
+	InboxColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "consumer_group", Type: field.TypeString},
+		{Name: "message_id", Type: field.TypeString},
+		{Name: "processed_at", Type: field.TypeTime},
+	}
+	// InboxTable holds the schema information for the "inbox" table.
+	InboxTable = &schema.Table{
+		Name:       "inbox",
+		Columns:    InboxColumns,
+		PrimaryKey: []*schema.Column{InboxColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "inbox_group_message_key",
+				Unique:  true,
+				Columns: []*schema.Column{InboxColumns[1], InboxColumns[2]},
+			},
+			{
+				Name:    "inbox_processed_at_idx",
+				Unique:  false,
+				Columns: []*schema.Column{InboxColumns[3]},
+			},
+		},
+	}
+	// OutboxColumns holds the columns for the "outbox" table.
+	OutboxColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "message_id", Type: field.TypeString, Unique: true},
+		{Name: "topic", Type: field.TypeString},
+		{Name: "aggregate_key", Type: field.TypeString},
+		{Name: "type", Type: field.TypeString},
+		{Name: "payload", Type: field.TypeJSON},
+		{Name: "headers", Type: field.TypeJSON, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "available_at", Type: field.TypeTime},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "last_error", Type: field.TypeString, Nullable: true},
+		{Name: "published_at", Type: field.TypeTime, Nullable: true},
+	}
+	// OutboxTable holds the schema information for the "outbox" table.
+	OutboxTable = &schema.Table{
+		Name:       "outbox",
+		Columns:    OutboxColumns,
+		PrimaryKey: []*schema.Column{OutboxColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "outbox_pending_idx",
+				Unique:  false,
+				Columns: []*schema.Column{OutboxColumns[0]},
+				Annotation: &entsql.IndexAnnotation{
+					Where: "published_at IS NULL",
+				},
+			},
+			{
+				Name:    "outbox_published_at_idx",
+				Unique:  false,
+				Columns: []*schema.Column{OutboxColumns[11]},
+			},
+		},
+	}
 	// UsersColumns holds the columns for the "users" table.
 	UsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
 		{Name: "email", Type: field.TypeString, Unique: true},
 		{Name: "name", Type: field.TypeString},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "version", Type: field.TypeInt64, Default: 1},
 	}
 	// UsersTable holds the schema information for the "users" table.
 	UsersTable = &schema.Table{
@@ -24,9 +113,21 @@ var (
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		AuditLogTable,
+		InboxTable,
+		OutboxTable,
 		UsersTable,
 	}
 )
 
 func init() {
+	AuditLogTable.Annotation = &entsql.Annotation{
+		Table: "audit_log",
+	}
+	InboxTable.Annotation = &entsql.Annotation{
+		Table: "inbox",
+	}
+	OutboxTable.Annotation = &entsql.Annotation{
+		Table: "outbox",
+	}
 }
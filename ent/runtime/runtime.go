@@ -2,7 +2,111 @@
 
 package runtime
 
-// The schema-stitching logic is generated in kabsa/ent/runtime.go
+import (
+	"kabsa/ent/auditentry"
+	"kabsa/ent/inboxmessage"
+	"kabsa/ent/outboxmessage"
+	"kabsa/ent/schema"
+	"kabsa/ent/user"
+	"time"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	auditentryFields := schema.AuditEntry{}.Fields()
+	_ = auditentryFields
+	// auditentryDescActor is the schema descriptor for actor field.
+	auditentryDescActor := auditentryFields[1].Descriptor()
+	// auditentry.ActorValidator is a validator for the "actor" field. It is called by the builders before save.
+	auditentry.ActorValidator = auditentryDescActor.Validators[0].(func(string) error)
+	// auditentryDescAction is the schema descriptor for action field.
+	auditentryDescAction := auditentryFields[2].Descriptor()
+	// auditentry.ActionValidator is a validator for the "action" field. It is called by the builders before save.
+	auditentry.ActionValidator = auditentryDescAction.Validators[0].(func(string) error)
+	// auditentryDescEntityType is the schema descriptor for entity_type field.
+	auditentryDescEntityType := auditentryFields[3].Descriptor()
+	// auditentry.EntityTypeValidator is a validator for the "entity_type" field. It is called by the builders before save.
+	auditentry.EntityTypeValidator = auditentryDescEntityType.Validators[0].(func(string) error)
+	// auditentryDescCreatedAt is the schema descriptor for created_at field.
+	auditentryDescCreatedAt := auditentryFields[8].Descriptor()
+	// auditentry.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditentry.DefaultCreatedAt = auditentryDescCreatedAt.Default.(func() time.Time)
+	inboxmessageFields := schema.InboxMessage{}.Fields()
+	_ = inboxmessageFields
+	// inboxmessageDescConsumerGroup is the schema descriptor for consumer_group field.
+	inboxmessageDescConsumerGroup := inboxmessageFields[1].Descriptor()
+	// inboxmessage.ConsumerGroupValidator is a validator for the "consumer_group" field. It is called by the builders before save.
+	inboxmessage.ConsumerGroupValidator = inboxmessageDescConsumerGroup.Validators[0].(func(string) error)
+	// inboxmessageDescMessageID is the schema descriptor for message_id field.
+	inboxmessageDescMessageID := inboxmessageFields[2].Descriptor()
+	// inboxmessage.MessageIDValidator is a validator for the "message_id" field. It is called by the builders before save.
+	inboxmessage.MessageIDValidator = inboxmessageDescMessageID.Validators[0].(func(string) error)
+	// inboxmessageDescProcessedAt is the schema descriptor for processed_at field.
+	inboxmessageDescProcessedAt := inboxmessageFields[3].Descriptor()
+	// inboxmessage.DefaultProcessedAt holds the default value on creation for the processed_at field.
+	inboxmessage.DefaultProcessedAt = inboxmessageDescProcessedAt.Default.(func() time.Time)
+	outboxmessageFields := schema.OutboxMessage{}.Fields()
+	_ = outboxmessageFields
+	// outboxmessageDescMessageID is the schema descriptor for message_id field.
+	outboxmessageDescMessageID := outboxmessageFields[1].Descriptor()
+	// outboxmessage.MessageIDValidator is a validator for the "message_id" field. It is called by the builders before save.
+	outboxmessage.MessageIDValidator = outboxmessageDescMessageID.Validators[0].(func(string) error)
+	// outboxmessageDescTopic is the schema descriptor for topic field.
+	outboxmessageDescTopic := outboxmessageFields[2].Descriptor()
+	// outboxmessage.TopicValidator is a validator for the "topic" field. It is called by the builders before save.
+	outboxmessage.TopicValidator = outboxmessageDescTopic.Validators[0].(func(string) error)
+	// outboxmessageDescType is the schema descriptor for type field.
+	outboxmessageDescType := outboxmessageFields[4].Descriptor()
+	// outboxmessage.TypeValidator is a validator for the "type" field. It is called by the builders before save.
+	outboxmessage.TypeValidator = outboxmessageDescType.Validators[0].(func(string) error)
+	// outboxmessageDescCreatedAt is the schema descriptor for created_at field.
+	outboxmessageDescCreatedAt := outboxmessageFields[7].Descriptor()
+	// outboxmessage.DefaultCreatedAt holds the default value on creation for the created_at field.
+	outboxmessage.DefaultCreatedAt = outboxmessageDescCreatedAt.Default.(func() time.Time)
+	// outboxmessageDescAvailableAt is the schema descriptor for available_at field.
+	outboxmessageDescAvailableAt := outboxmessageFields[8].Descriptor()
+	// outboxmessage.DefaultAvailableAt holds the default value on creation for the available_at field.
+	outboxmessage.DefaultAvailableAt = outboxmessageDescAvailableAt.Default.(func() time.Time)
+	// outboxmessageDescAttempts is the schema descriptor for attempts field.
+	outboxmessageDescAttempts := outboxmessageFields[9].Descriptor()
+	// outboxmessage.DefaultAttempts holds the default value on creation for the attempts field.
+	outboxmessage.DefaultAttempts = outboxmessageDescAttempts.Default.(int)
+	// outboxmessage.AttemptsValidator is a validator for the "attempts" field. It is called by the builders before save.
+	outboxmessage.AttemptsValidator = outboxmessageDescAttempts.Validators[0].(func(int) error)
+	userMixin := schema.User{}.Mixin()
+	userMixinHooks0 := userMixin[0].Hooks()
+	user.Hooks[0] = userMixinHooks0[0]
+	userMixinInters0 := userMixin[0].Interceptors()
+	user.Interceptors[0] = userMixinInters0[0]
+	userFields := schema.User{}.Fields()
+	_ = userFields
+	// userDescEmail is the schema descriptor for email field.
+	userDescEmail := userFields[1].Descriptor()
+	// user.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	user.EmailValidator = userDescEmail.Validators[0].(func(string) error)
+	// userDescName is the schema descriptor for name field.
+	userDescName := userFields[2].Descriptor()
+	// user.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	user.NameValidator = userDescName.Validators[0].(func(string) error)
+	// userDescCreatedAt is the schema descriptor for created_at field.
+	userDescCreatedAt := userFields[3].Descriptor()
+	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
+	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
+	// userDescUpdatedAt is the schema descriptor for updated_at field.
+	userDescUpdatedAt := userFields[4].Descriptor()
+	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
+	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	user.UpdateDefaultUpdatedAt = userDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// userDescVersion is the schema descriptor for version field.
+	userDescVersion := userFields[5].Descriptor()
+	// user.DefaultVersion holds the default value on creation for the version field.
+	user.DefaultVersion = userDescVersion.Default.(int64)
+	// user.VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	user.VersionValidator = userDescVersion.Validators[0].(func(int64) error)
+}
 
 const (
 	Version = "v0.14.5"                                         // Version of ent codegen.
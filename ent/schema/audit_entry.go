@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditEntry records one mutation of an entity, written in the same
+// transaction as the mutation. Entries are append-only.
+type AuditEntry struct {
+	ent.Schema
+}
+
+func (AuditEntry) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "audit_log"},
+	}
+}
+
+func (AuditEntry) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").
+			Unique().
+			Immutable(),
+
+		field.String("actor").
+			Immutable().
+			NotEmpty().
+			Comment("Who made the change: a user reference or system:<job>"),
+
+		field.String("action").
+			Immutable().
+			NotEmpty(),
+
+		field.String("entity_type").
+			Immutable().
+			NotEmpty(),
+
+		field.Int64("entity_id").
+			Immutable(),
+
+		field.JSON("changes", json.RawMessage{}).
+			Immutable().
+			Comment("Changed fields as {field: {from, to}}"),
+
+		field.String("request_id").
+			Immutable().
+			Optional(),
+
+		field.String("trace_id").
+			Immutable().
+			Optional(),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+func (AuditEntry) Indexes() []ent.Index {
+	return []ent.Index{
+		// History is read per entity, newest first.
+		index.Fields("entity_type", "entity_id", "id").
+			StorageKey("audit_log_entity_idx"),
+	}
+}
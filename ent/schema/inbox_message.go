@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// InboxMessage records that a consumer group processed a Kafka message. It
+// is written in the same transaction as the handler's side effects, so a
+// redelivered message is recognised and skipped.
+type InboxMessage struct {
+	ent.Schema
+}
+
+func (InboxMessage) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "inbox"},
+	}
+}
+
+func (InboxMessage) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").
+			Unique().
+			Immutable(),
+
+		field.String("consumer_group").
+			Immutable().
+			NotEmpty(),
+
+		field.String("message_id").
+			Immutable().
+			NotEmpty().
+			Comment("Envelope.MessageID"),
+
+		field.Time("processed_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+func (InboxMessage) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("consumer_group", "message_id").
+			Unique().
+			StorageKey("inbox_group_message_key"),
+		// Pruning deletes by age.
+		index.Fields("processed_at").
+			StorageKey("inbox_processed_at_idx"),
+	}
+}
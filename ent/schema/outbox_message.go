@@ -0,0 +1,92 @@
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// OutboxMessage is an event written in the same transaction as the change
+// it describes, waiting to be relayed to Kafka.
+type OutboxMessage struct {
+	ent.Schema
+}
+
+func (OutboxMessage) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "outbox"},
+	}
+}
+
+func (OutboxMessage) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").
+			Unique().
+			Immutable().
+			Comment("Primary key; also the publish order"),
+
+		field.String("message_id").
+			Unique().
+			Immutable().
+			NotEmpty().
+			Comment("Envelope.MessageID, stable across publish retries"),
+
+		field.String("topic").
+			Immutable().
+			NotEmpty(),
+
+		field.String("aggregate_key").
+			Immutable().
+			Comment("Kafka partition key; rows with the same key are published in id order"),
+
+		field.String("type").
+			Immutable().
+			NotEmpty().
+			Comment("Envelope.Type"),
+
+		field.JSON("payload", json.RawMessage{}).
+			Immutable().
+			Comment("Envelope.Payload"),
+
+		field.JSON("headers", map[string]string{}).
+			Optional().
+			Immutable().
+			Comment("Trace context, request and correlation ID of the writer, sent as message headers"),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("Envelope.OccurredAt"),
+
+		field.Time("available_at").
+			Default(time.Now).
+			Comment("Not retried before this time"),
+
+		field.Int("attempts").
+			Default(0).
+			NonNegative(),
+
+		field.String("last_error").
+			Optional(),
+
+		field.Time("published_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+func (OutboxMessage) Indexes() []ent.Index {
+	return []ent.Index{
+		// The relay scans pending rows in id order.
+		index.Fields("id").
+			Annotations(entsql.IndexWhere("published_at IS NULL")).
+			StorageKey("outbox_pending_idx"),
+		index.Fields("published_at").
+			StorageKey("outbox_published_at_idx"),
+	}
+}
@@ -0,0 +1,168 @@
+package seed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	appuser "kabsa/internal/app/user"
+	"kabsa/internal/logging"
+)
+
+// memService is an appuser.Service over a slice, recording mutations.
+type memService struct {
+	appuser.Service // unused methods panic
+	users           []appuser.UserDto
+	calls           []string
+}
+
+func (m *memService) List(_ context.Context, in appuser.ListUsersInput) (*appuser.UserListDto, error) {
+	page := &appuser.UserListDto{}
+	for _, u := range m.users {
+		if strings.EqualFold(u.Email, in.Email) && (in.IncludeDeleted || u.DeletedAt == nil) {
+			page.Items = append(page.Items, u)
+		}
+	}
+	return page, nil
+}
+
+func (m *memService) Create(_ context.Context, in appuser.CreateUserInput) (*appuser.UserDto, error) {
+	m.calls = append(m.calls, "create "+in.Email)
+	u := appuser.UserDto{Id: int64(len(m.users) + 1), Email: in.Email, Name: in.Name, Version: 1}
+	m.users = append(m.users, u)
+	return &u, nil
+}
+
+func (m *memService) Update(_ context.Context, in appuser.UpdateUserInput) (*appuser.UserDto, error) {
+	u := &m.users[in.ID-1]
+	m.calls = append(m.calls, "update "+u.Email)
+	u.Name = *in.Name
+	u.Version++
+	return u, nil
+}
+
+func (m *memService) Restore(_ context.Context, id int64) (*appuser.UserDto, error) {
+	u := &m.users[id-1]
+	m.calls = append(m.calls, "restore "+u.Email)
+	u.DeletedAt = nil
+	u.Version++
+	return u, nil
+}
+
+func TestSeeder_UpsertsByEmail(t *testing.T) {
+	deleted := time.Now()
+	svc := &memService{users: []appuser.UserDto{
+		{Id: 1, Email: "same@example.com", Name: "Same", Version: 1},
+		{Id: 2, Email: "renamed@example.com", Name: "Old", Version: 1},
+		{Id: 3, Email: "gone@example.com", Name: "Gone", Version: 2, DeletedAt: &deleted},
+	}}
+	fixtures := &Fixtures{Users: []UserFixture{
+		{Email: "same@example.com", Name: "Same"},
+		{Email: "renamed@example.com", Name: "New"},
+		{Email: "gone@example.com", Name: "Back"},
+		{Email: "new@example.com", Name: "New User"},
+	}}
+	seeder := NewSeeder(svc, logging.NewNop())
+
+	report, err := seeder.Run(context.Background(), fixtures, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(svc.calls) != 0 {
+		t.Fatalf("dry run mutated: %v", svc.calls)
+	}
+	want := map[Action]int{Unchanged: 1, Updated: 1, Restored: 1, Created: 1}
+	for action, n := range want {
+		if report.Users[action] != n {
+			t.Errorf("dry run %s = %d, want %d", action, report.Users[action], n)
+		}
+	}
+
+	if _, err := seeder.Run(context.Background(), fixtures, false); err != nil {
+		t.Fatal(err)
+	}
+	wantCalls := "update renamed@example.com,restore gone@example.com,update gone@example.com,create new@example.com"
+	if got := strings.Join(svc.calls, ","); got != wantCalls {
+		t.Errorf("calls %s, want %s", got, wantCalls)
+	}
+
+	// A second run is a no-op.
+	svc.calls = nil
+	report, err = seeder.Run(context.Background(), fixtures, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(svc.calls) != 0 || report.Users[Unchanged] != 4 {
+		t.Errorf("rerun calls %v, report %v", svc.calls, report.Users)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "01-users.yaml", "users:\n  - email: a@example.com\n    name: Ann\n")
+	// Emails differing only in case are different users, as in the database.
+	writeFile(t, dir, "02-users.json", `{"users": [{"email": "b@example.com", "name": "Bob"}, {"email": "A@example.com", "name": "Al"}]}`)
+	writeFile(t, dir, "notes.txt", "ignored")
+
+	f, err := LoadFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Users) != 3 || f.Users[0].Email != "a@example.com" || f.Users[1].Email != "b@example.com" || f.Users[2].Email != "A@example.com" {
+		t.Errorf("got %+v", f.Users)
+	}
+}
+
+func TestLoadFiles_Rejects(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":        "users:\n  - email: a@example.com\n    nmae: Ann\n",
+		"bad email":          "users:\n  - email: not-an-email\n    name: Ann\n",
+		"display name email": "users:\n  - email: 'Ann <a@example.com>'\n    name: Ann\n",
+		"blank name":         "users:\n  - email: a@example.com\n    name: ' '\n",
+		"short name":         "users:\n  - email: a@example.com\n    name: A\n",
+		"long name":          "users:\n  - email: a@example.com\n    name: " + strings.Repeat("a", 101) + "\n",
+		"duplicate":          "users:\n  - {email: a@example.com, name: Ann}\n  - {email: a@example.com, name: Bob}\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), "users.yaml", content)
+			if _, err := LoadFiles(path); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestSeeder_MatchesEmailExactly(t *testing.T) {
+	svc := &memService{users: []appuser.UserDto{
+		{Id: 1, Email: "Jane@example.com", Name: "Jane Upper", Version: 1},
+		{Id: 2, Email: "jane@example.com", Name: "Jane Lower", Version: 1},
+	}}
+	fixtures := &Fixtures{Users: []UserFixture{
+		{Email: "jane@example.com", Name: "Jane Lower"},
+		{Email: "JANE@example.com", Name: "Jane Shouting"},
+	}}
+
+	report, err := NewSeeder(svc, logging.NewNop()).Run(context.Background(), fixtures, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Users[Unchanged] != 1 || report.Users[Created] != 1 {
+		t.Errorf("report %v, want one unchanged and one created", report.Users)
+	}
+	if got := strings.Join(svc.calls, ","); got != "create JANE@example.com" {
+		t.Errorf("calls %s, want only the create", got)
+	}
+}
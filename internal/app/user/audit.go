@@ -0,0 +1,95 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"kabsa/internal/domain/audit"
+	dom "kabsa/internal/domain/user"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const auditEntityType = "user"
+
+// PurgeActor is the audit actor of users removed by the PurgeJob.
+const PurgeActor = "system:purge"
+
+// auditFields is the part of a user its history tracks. Version and
+// timestamps change on every write and deletion is the entry's action, so
+// they would only add noise.
+func auditFields(u *dom.User) map[string]any {
+	if u == nil {
+		return nil
+	}
+	return map[string]any{
+		"email": u.Email,
+		"name":  u.Name,
+	}
+}
+
+// writeAudit records action on user id, attributing it to the actor and
+// request in ctx. It must run in the transaction of the change so the two
+// commit together; with no audit repository configured it does nothing.
+func writeAudit(ctx context.Context, log audit.Repository, action audit.Action, id int64, before, after *dom.User) error {
+	if log == nil {
+		return nil
+	}
+
+	e := &audit.Entry{
+		Actor:      audit.ActorFrom(ctx),
+		Action:     action,
+		EntityType: auditEntityType,
+		EntityID:   id,
+		Changes:    audit.Diff(auditFields(before), auditFields(after)),
+		RequestID:  audit.RequestIDFrom(ctx),
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		e.TraceID = sc.TraceID().String()
+	}
+
+	if err := log.Append(ctx, e); err != nil {
+		return fmt.Errorf("audit %s: %w", action, err)
+	}
+	return nil
+}
+
+func (s *service) History(ctx context.Context, id int64, input HistoryInput) (*HistoryDto, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+	page := &HistoryDto{Items: []AuditEntryDto{}, Limit: limit}
+
+	var entries []audit.Entry
+	if s.audit != nil {
+		// One extra row tells whether there is a next page.
+		var err error
+		entries, err = s.audit.List(ctx, auditEntityType, id, audit.ListFilter{
+			Limit:    limit + 1,
+			BeforeID: input.Before,
+		})
+		if err != nil {
+			s.logger.Error("failed to list user history", "error", err, "id", id)
+			return nil, fmt.Errorf("list user history: %w", err)
+		}
+	}
+
+	// History outlives the user, so only a user with neither is missing.
+	if len(entries) == 0 && input.Before == 0 {
+		if _, err := s.repo.GetById(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+		page.NextBefore = entries[limit-1].ID
+	}
+	for _, e := range entries {
+		page.Items = append(page.Items, toAuditDTO(e))
+	}
+	return page, nil
+}
@@ -0,0 +1,87 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appuser "kabsa/internal/app/user"
+	"kabsa/internal/db/dbtest"
+	"kabsa/internal/db/repository"
+	"kabsa/internal/domain/audit"
+	"kabsa/internal/logging"
+	"kabsa/internal/pagination"
+)
+
+// missCache never has anything cached.
+type missCache struct{}
+
+func (missCache) GetByID(context.Context, int64) ([]byte, error)          { return nil, nil }
+func (missCache) Set(context.Context, int64, []byte, time.Duration) error { return nil }
+func (missCache) Delete(context.Context, int64) error                     { return nil }
+
+func TestAudit_RecordsWhatDeleteRestoreAndPurgeTouched(t *testing.T) {
+	client := dbtest.NewClient(t)
+	ctx := context.Background()
+	repo := repository.NewUserRepository(client, logging.NewNop())
+	auditLog := repository.NewAuditRepository(client, logging.NewNop())
+	codec, err := pagination.NewCursorCodec("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := appuser.NewService(repo, missCache{}, client, appuser.NoopEvents{}, auditLog, codec, logging.NewNop())
+
+	u, err := svc.Create(ctx, appuser.CreateUserInput{Email: "gone@example.com", Name: "Gone"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Delete(ctx, appuser.DeleteUserInput{ID: u.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Restore(ctx, u.Id); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Delete(ctx, appuser.DeleteUserInput{ID: u.Id}); err != nil {
+		t.Fatal(err)
+	}
+	purge := appuser.NewPurgeJob(repo, missCache{}, client, appuser.NoopEvents{}, auditLog, -time.Second, time.Hour, logging.NewNop())
+	if n, err := purge.RunOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("purge = %d, %v; want 1 user", n, err)
+	}
+
+	history, err := svc.History(ctx, u.Id, appuser.HistoryInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFrom := map[string]any{"email": "gone@example.com", "name": "Gone"}
+	// Newest first: purged, deleted, restored, deleted, created.
+	for i, want := range []struct {
+		action   audit.Action
+		from, to map[string]any
+	}{
+		{audit.ActionPurged, wantFrom, nil},
+		{audit.ActionDeleted, wantFrom, nil},
+		{audit.ActionRestored, nil, wantFrom},
+		{audit.ActionDeleted, wantFrom, nil},
+		{audit.ActionCreated, nil, wantFrom},
+	} {
+		if i >= len(history.Items) {
+			t.Fatalf("history has %d entries, want 5", len(history.Items))
+		}
+		e := history.Items[i]
+		if e.Action != string(want.action) {
+			t.Errorf("entry %d: action %s, want %s", i, e.Action, want.action)
+			continue
+		}
+		for field, from := range want.from {
+			if got := e.Changes[field].From; got != from {
+				t.Errorf("%s: %s from %v, want %v", e.Action, field, got, from)
+			}
+		}
+		for field, to := range want.to {
+			if got := e.Changes[field].To; got != to {
+				t.Errorf("%s: %s to %v, want %v", e.Action, field, got, to)
+			}
+		}
+	}
+}
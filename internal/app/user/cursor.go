@@ -0,0 +1,98 @@
+package user
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	domcommon "kabsa/internal/domain/common"
+	dom "kabsa/internal/domain/user"
+	"time"
+)
+
+var (
+	errInvalidCursor = domcommon.NewFieldValidation("cursor", "cursor", "is malformed or was not issued by this API")
+	errCursorFilters = domcommon.NewFieldValidation("cursor", "cursor", "was issued for different filters; repeat the filters of the first page")
+)
+
+// listCursor is the signed payload behind ListUsersInput.Cursor. It pins the
+// sort order and the filters so a cursor can't be replayed against a
+// different ordering or a different result set.
+type listCursor struct {
+	SortBy   dom.SortField `json:"s"`
+	SortDesc bool          `json:"d,omitempty"`
+	Filters  string        `json:"f"`
+	ID       int64         `json:"id"`
+	Str      string        `json:"v,omitempty"`
+	Time     *time.Time    `json:"t,omitempty"`
+}
+
+// afterUser is the keyset position just past u in filter's sort order.
+func afterUser(filter dom.ListFilter, u dom.User) *dom.Cursor {
+	after := &dom.Cursor{ID: u.ID}
+	switch filter.SortBy {
+	case dom.SortByEmail:
+		after.Value = u.Email
+	case dom.SortByName:
+		after.Value = u.Name
+	case dom.SortByCreatedAt:
+		after.Value = u.CreatedAt
+	}
+	return after
+}
+
+func (s *service) encodeCursor(filter dom.ListFilter, last dom.User) (string, error) {
+	c := listCursor{SortBy: filter.SortBy, SortDesc: filter.SortDesc, Filters: filterHash(filter), ID: last.ID}
+	switch filter.SortBy {
+	case dom.SortByEmail:
+		c.Str = last.Email
+	case dom.SortByName:
+		c.Str = last.Name
+	case dom.SortByCreatedAt:
+		t := last.CreatedAt
+		c.Time = &t
+	}
+	return s.cursors.Encode(c)
+}
+
+// applyCursor decodes token and switches filter to keyset mode after it.
+// The filters in the request must be the ones the cursor was issued for.
+func (s *service) applyCursor(filter *dom.ListFilter, token string) error {
+	var c listCursor
+	if err := s.cursors.Decode(token, &c); err != nil || !c.SortBy.Valid() {
+		return errInvalidCursor
+	}
+	if c.Filters != filterHash(*filter) {
+		return errCursorFilters
+	}
+
+	after := &dom.Cursor{ID: c.ID}
+	switch c.SortBy {
+	case dom.SortByEmail, dom.SortByName:
+		after.Value = c.Str
+	case dom.SortByCreatedAt:
+		if c.Time == nil {
+			return errInvalidCursor
+		}
+		after.Value = *c.Time
+	}
+
+	filter.SortBy = c.SortBy
+	filter.SortDesc = c.SortDesc
+	filter.After = after
+	filter.Offset = 0
+	return nil
+}
+
+// filterHash fingerprints the filters that decide which rows a listing
+// contains. Paging and sort fields are left out: the cursor carries those.
+func filterHash(filter dom.ListFilter) string {
+	b, _ := json.Marshal(struct {
+		Email          string     `json:"e"`
+		NameContains   string     `json:"n"`
+		CreatedAfter   *time.Time `json:"a"`
+		CreatedBefore  *time.Time `json:"b"`
+		IncludeDeleted bool       `json:"x"`
+	}{filter.Email, filter.NameContains, filter.CreatedAfter, filter.CreatedBefore, filter.IncludeDeleted})
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
@@ -0,0 +1,116 @@
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	appuser "kabsa/internal/app/user"
+	"kabsa/internal/db/dbtest"
+	"kabsa/internal/db/repository"
+	domcommon "kabsa/internal/domain/common"
+	"kabsa/internal/logging"
+	"kabsa/internal/pagination"
+)
+
+func newCursorService(t *testing.T) appuser.Service {
+	t.Helper()
+
+	client := dbtest.NewClient(t)
+	codec, err := pagination.NewCursorCodec("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return appuser.NewService(
+		repository.NewUserRepository(client, logging.NewNop()),
+		missCache{},
+		client,
+		appuser.NoopEvents{},
+		repository.NewAuditRepository(client, logging.NewNop()),
+		codec,
+		logging.NewNop())
+}
+
+func TestList_CursorPagesThroughDuplicateSortValues(t *testing.T) {
+	svc := newCursorService(t)
+	ctx := context.Background()
+
+	var want []int64
+	for i := range 7 {
+		// Two distinct names so the keyset has to cross a tie boundary.
+		name := "Alex"
+		if i >= 4 {
+			name = "Sam"
+		}
+		u, err := svc.Create(ctx, appuser.CreateUserInput{Email: fmt.Sprintf("u%d@example.com", i), Name: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, u.Id)
+	}
+
+	var got []int64
+	input := appuser.ListUsersInput{Limit: 2, SortBy: "name"}
+	for pages := 0; ; pages++ {
+		if pages > len(want) {
+			t.Fatalf("still paging after %d pages", pages)
+		}
+		page, err := svc.List(ctx, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, u := range page.Items {
+			got = append(got, u.Id)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		input.Cursor = page.NextCursor
+	}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+}
+
+func TestList_CursorRejectsChangedFilters(t *testing.T) {
+	svc := newCursorService(t)
+	ctx := context.Background()
+
+	for i := range 3 {
+		if _, err := svc.Create(ctx, appuser.CreateUserInput{Email: fmt.Sprintf("u%d@example.com", i), Name: "Alex"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	first, err := svc.List(ctx, appuser.ListUsersInput{Limit: 1, NameContains: "alex"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.NextCursor == "" {
+		t.Fatal("expected a next cursor")
+	}
+
+	tests := []struct {
+		name    string
+		input   appuser.ListUsersInput
+		wantErr bool
+	}{
+		{name: "same filters", input: appuser.ListUsersInput{Limit: 1, NameContains: "alex"}},
+		{name: "different limit", input: appuser.ListUsersInput{Limit: 5, NameContains: "alex"}},
+		{name: "filter dropped", input: appuser.ListUsersInput{Limit: 1}, wantErr: true},
+		{name: "filter changed", input: appuser.ListUsersInput{Limit: 1, NameContains: "al"}, wantErr: true},
+		{name: "deleted included", input: appuser.ListUsersInput{Limit: 1, NameContains: "alex", IncludeDeleted: true}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.Cursor = first.NextCursor
+
+			_, err := svc.List(ctx, tt.input)
+
+			if tt.wantErr != domcommon.IsValidation(err) || (!tt.wantErr && err != nil) {
+				t.Errorf("err = %v, want validation error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
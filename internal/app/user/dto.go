@@ -1,7 +1,9 @@
 ﻿package user
 
 import (
+	"kabsa/internal/domain/audit"
 	dom "kabsa/internal/domain/user"
+	"kabsa/internal/validation"
 	"time"
 )
 
@@ -11,23 +13,77 @@ type UserDto struct {
 	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
+	Version   int64     `json:"version"`
+	// DeletedAt is only ever set in listings that include deleted users.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
 }
 
+// CreateUserInput carries the rules for a user's fields in its validate
+// tags; UpdateUserInput applies the same ones to the fields it changes.
 type CreateUserInput struct {
-	Email string
-	Name  string
+	Email string `json:"email" validate:"required,email,max=254"`
+	Name  string `json:"name" validate:"required,notblank,min=2,max=100"`
 }
 
+// Validate checks input against the user field rules.
+func (input CreateUserInput) Validate() error {
+	return validation.Struct(input)
+}
+
+// UpdateUserInput changes the non-nil fields and leaves the rest as they are.
+// A full replacement sets every field.
 type UpdateUserInput struct {
-	ID   int64
-	Name *string
+	ID    int64
+	Email *string `json:"email" validate:"omitempty,email,max=254"`
+	Name  *string `json:"name" validate:"omitempty,notblank,min=2,max=100"`
+	// ExpectedVersion, when set, makes the update conditional: it fails with
+	// a precondition error if the user has moved on to another version.
+	ExpectedVersion *int64
+}
+
+// Validate checks the fields input sets against the user field rules.
+func (input UpdateUserInput) Validate() error {
+	return validation.Struct(input)
+}
+
+type DeleteUserInput struct {
+	ID              int64
+	ExpectedVersion *int64 // see UpdateUserInput.ExpectedVersion
 }
 
 type ListUsersInput struct {
 	Limit  int
 	Offset int
+	// Cursor is a NextCursor from a previous page. When set, paging is keyset
+	// based, Offset is ignored and the sort order comes from the cursor.
+	Cursor string
+
+	SortBy   string // one of id, email, name, created_at; defaults to id
+	SortDesc bool
+
+	Email         string
+	NameContains  string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	IncludeDeleted bool // admin only: also list soft-deleted users
+}
+
+// UserListDto is one page of users. Total is only computed for offset
+// paging; it is nil when the page was requested with a cursor.
+type UserListDto struct {
+	Items      []UserDto
+	Total      *int
+	Limit      int
+	Offset     int
+	NextCursor string // empty on the last page
 }
 
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
 func toDTO(u *dom.User) *UserDto {
 	if u == nil {
 		return nil
@@ -38,6 +94,8 @@ func toDTO(u *dom.User) *UserDto {
 		Name:      u.Name,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
+		Version:   u.Version,
+		DeletedAt: u.DeletedAt,
 	}
 }
 
@@ -49,3 +107,39 @@ func toDTOs(list []dom.User) []UserDto {
 	}
 	return res
 }
+
+type HistoryInput struct {
+	Limit int
+	// Before is a NextBefore from a previous page; 0 starts at the newest.
+	Before int64
+}
+
+// AuditEntryDto is one change in a user's history.
+type AuditEntryDto struct {
+	Id        int64                   `json:"id"`
+	Actor     string                  `json:"actor"`
+	Action    string                  `json:"action"`
+	Changes   map[string]audit.Change `json:"changes"`
+	RequestId string                  `json:"requestId,omitempty"`
+	TraceId   string                  `json:"traceId,omitempty"`
+	CreatedAt time.Time               `json:"createdAt"`
+}
+
+// HistoryDto is one page of a user's history, newest first.
+type HistoryDto struct {
+	Items      []AuditEntryDto
+	Limit      int
+	NextBefore int64 // 0 on the last page
+}
+
+func toAuditDTO(e audit.Entry) AuditEntryDto {
+	return AuditEntryDto{
+		Id:        e.ID,
+		Actor:     e.Actor,
+		Action:    string(e.Action),
+		Changes:   e.Changes,
+		RequestId: e.RequestID,
+		TraceId:   e.TraceID,
+		CreatedAt: e.CreatedAt,
+	}
+}
@@ -2,6 +2,7 @@
 
 import (
 	domcommon "kabsa/internal/domain/common"
+	dom "kabsa/internal/domain/user"
 )
 
 func IsNotFound(err error) bool {
@@ -9,5 +10,7 @@ func IsNotFound(err error) bool {
 }
 
 func NewUserNotFoundError() error {
-	return domcommon.NewNotFound("user")
+	return dom.ErrNotFound
 }
+
+var errConcurrentUpdate = domcommon.NewConflict("user", "", "user was modified concurrently, retry the request")
@@ -5,7 +5,13 @@ import "context"
 type Events interface {
 	UserCreated(ctx context.Context, u *UserDto) error
 	UserUpdated(ctx context.Context, u *UserDto) error
-	UserDeleted(ctx context.Context, id int64) error
+	// UserEmailChanged is emitted in addition to UserUpdated when the email
+	// changes, so consumers keyed on email don't have to diff updates.
+	UserEmailChanged(ctx context.Context, id int64, oldEmail, newEmail string) error
+	// UserDeleted is emitted with hard=false when a user is soft-deleted and
+	// again with hard=true when the row is purged.
+	UserDeleted(ctx context.Context, id int64, hard bool) error
+	UserRestored(ctx context.Context, u *UserDto) error
 }
 
 // NoopEvents No-op implementation, useful for tests or if you don’t need events yet.
@@ -13,4 +19,8 @@ type NoopEvents struct{}
 
 func (NoopEvents) UserCreated(ctx context.Context, u *UserDto) error { return nil }
 func (NoopEvents) UserUpdated(ctx context.Context, u *UserDto) error { return nil }
-func (NoopEvents) UserDeleted(ctx context.Context, id int64) error   { return nil }
+func (NoopEvents) UserEmailChanged(ctx context.Context, id int64, oldEmail, newEmail string) error {
+	return nil
+}
+func (NoopEvents) UserDeleted(ctx context.Context, id int64, hard bool) error { return nil }
+func (NoopEvents) UserRestored(ctx context.Context, u *UserDto) error         { return nil }
@@ -0,0 +1,35 @@
+package user
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExportBatchSize is how many users Export reads, and hands over, at a time.
+const ExportBatchSize = 1000
+
+func (s *service) Export(ctx context.Context, input ListUsersInput, fn func([]UserDto) error) error {
+	filter := listFilter(input)
+	filter.Limit = ExportBatchSize
+	filter.Offset = 0
+
+	// Keyset paging keeps every batch as cheap as the first and doesn't
+	// skip or repeat rows when users are created or deleted meanwhile.
+	for {
+		users, err := s.repo.List(ctx, filter)
+		if err != nil {
+			s.logger.Error("failed to export users", "error", err)
+			return fmt.Errorf("export users: %w", err)
+		}
+		if len(users) == 0 {
+			return nil
+		}
+		if err := fn(toDTOs(users)); err != nil {
+			return err
+		}
+		if len(users) < ExportBatchSize {
+			return nil
+		}
+		filter.After = afterUser(filter, users[len(users)-1])
+	}
+}
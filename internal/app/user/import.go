@@ -0,0 +1,98 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kabsa/internal/domain/audit"
+	dom "kabsa/internal/domain/user"
+)
+
+// MaxImportBatch is the most rows Import takes per call; callers streaming a
+// larger file split it.
+const MaxImportBatch = 500
+
+// ImportResult is the outcome of one Import row: the created user or why
+// that row was rejected.
+type ImportResult struct {
+	User *UserDto
+	Err  error
+}
+
+func (s *service) Import(ctx context.Context, rows []CreateUserInput) ([]ImportResult, error) {
+	if len(rows) > MaxImportBatch {
+		return nil, fmt.Errorf("import: %d rows exceeds the batch limit of %d", len(rows), MaxImportBatch)
+	}
+
+	results := make([]ImportResult, len(rows))
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		clear(results)
+		return s.importBatch(ctx, rows, results)
+	})
+	if errors.Is(err, dom.ErrEmailTaken) {
+		// Someone took an email between the check and the insert, which
+		// fails the whole statement. Fall back to one row at a time so only
+		// the conflicting rows are rejected.
+		for i, row := range rows {
+			dto, err := s.Create(ctx, row)
+			if err != nil && !errors.Is(err, dom.ErrEmailTaken) {
+				return nil, err
+			}
+			results[i] = ImportResult{User: dto, Err: err}
+		}
+		return results, nil
+	}
+	if err != nil {
+		s.logger.Error("failed to import users", "error", err, "rows", len(rows))
+		return nil, fmt.Errorf("import users: %w", err)
+	}
+	return results, nil
+}
+
+// importBatch rejects rows whose email is taken, in the database or by an
+// earlier row, then inserts the rest with one statement. Every created user
+// gets its own event and audit entry, as with Create.
+func (s *service) importBatch(ctx context.Context, rows []CreateUserInput, results []ImportResult) error {
+	emails := make([]string, len(rows))
+	for i, row := range rows {
+		emails[i] = row.Email
+	}
+	taken, err := s.repo.TakenEmails(ctx, emails)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(rows)+len(taken))
+	for _, email := range taken {
+		seen[email] = true
+	}
+
+	var (
+		users []*dom.User
+		index []int // users[k] is rows[index[k]]
+	)
+	for i, row := range rows {
+		if seen[row.Email] {
+			results[i].Err = dom.ErrEmailTaken
+			continue
+		}
+		seen[row.Email] = true
+		users = append(users, &dom.User{Email: row.Email, Name: row.Name})
+		index = append(index, i)
+	}
+
+	if err := s.repo.CreateBulk(ctx, users); err != nil {
+		return err
+	}
+
+	for k, u := range users {
+		if err := writeAudit(ctx, s.audit, audit.ActionCreated, u.ID, nil, u); err != nil {
+			return err
+		}
+		dto := toDTO(u)
+		if err := s.events.UserCreated(ctx, dto); err != nil {
+			return fmt.Errorf("UserCreated event: %w", err)
+		}
+		results[index[k]].User = dto
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"kabsa/internal/cache"
+	"kabsa/internal/db"
+	"kabsa/internal/domain/audit"
+	dom "kabsa/internal/domain/user"
+	"kabsa/internal/logging"
+	"time"
+)
+
+// PurgeJob hard-deletes users that have been soft-deleted for longer than
+// the retention period, emitting UserDeleted(hard=true) for each and
+// auditing it as PurgeActor.
+type PurgeJob struct {
+	repo      dom.Repository
+	cache     cache.UserCache
+	tx        db.Transactor // optional, as for the service
+	events    Events
+	audit     audit.Repository // optional
+	retention time.Duration
+	interval  time.Duration
+	batchSize int
+	logger    logging.Logger
+}
+
+const defaultPurgeBatchSize = 500
+
+func NewPurgeJob(
+	repo dom.Repository,
+	cache cache.UserCache,
+	tx db.Transactor,
+	events Events,
+	auditLog audit.Repository,
+	retention, interval time.Duration,
+	logger logging.Logger,
+) *PurgeJob {
+	return &PurgeJob{
+		repo:      repo,
+		cache:     cache,
+		tx:        tx,
+		events:    events,
+		audit:     auditLog,
+		retention: retention,
+		interval:  interval,
+		batchSize: defaultPurgeBatchSize,
+		logger:    logger.With("component", "user_purge_job"),
+	}
+}
+
+// Run purges once per interval until ctx is cancelled.
+func (j *PurgeJob) Run(ctx context.Context) error {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := j.RunOnce(ctx); err != nil {
+			j.logger.Error("user purge failed", "error", err, "purged", n)
+		} else if n > 0 {
+			j.logger.Info("purged soft-deleted users", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce purges every user deleted before now minus retention, in batches,
+// and returns how many were removed.
+func (j *PurgeJob) RunOnce(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-j.retention)
+	ctx = audit.WithActor(ctx, PurgeActor)
+	total := 0
+
+	for {
+		ids, err := j.purgeBatch(ctx, cutoff)
+		if err != nil {
+			return total, fmt.Errorf("purge users: %w", err)
+		}
+		total += len(ids)
+
+		for _, id := range ids {
+			if err := j.cache.Delete(ctx, id); err != nil {
+				j.logger.Error("failed to delete user cache after purge", "error", err, "id", id)
+			}
+		}
+		if len(ids) < j.batchSize || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
+
+// purgeBatch deletes one batch and records its UserDeleted events and audit
+// entries in the same transaction.
+func (j *PurgeJob) purgeBatch(ctx context.Context, cutoff time.Time) ([]int64, error) {
+	var ids []int64
+	fn := func(ctx context.Context) error {
+		purged, err := j.repo.Purge(ctx, cutoff, j.batchSize)
+		if err != nil {
+			return err
+		}
+		ids = make([]int64, 0, len(purged))
+		for i := range purged {
+			u := &purged[i]
+			ids = append(ids, u.ID)
+			if err := j.events.UserDeleted(ctx, u.ID, true); err != nil {
+				return fmt.Errorf("UserDeleted event: %w", err)
+			}
+			// The audit entry is the only record left of what was removed.
+			if err := writeAudit(ctx, j.audit, audit.ActionPurged, u.ID, u, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var err error
+	if j.tx == nil {
+		err = fn(ctx)
+	} else {
+		err = j.tx.WithTx(ctx, fn)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -3,43 +3,104 @@
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"kabsa/internal/cache"
 	"kabsa/internal/db"
+	"kabsa/internal/domain/audit"
 	dom "kabsa/internal/domain/user"
 	"kabsa/internal/logging"
+	"kabsa/internal/pagination"
 	"time"
 )
 
 type Service interface {
-	List(ctx context.Context, input ListUsersInput) ([]UserDto, error)
+	List(ctx context.Context, input ListUsersInput) (*UserListDto, error)
 	GetById(ctx context.Context, id int64) (*UserDto, error)
 	Create(ctx context.Context, input CreateUserInput) (*UserDto, error)
 	Update(ctx context.Context, input UpdateUserInput) (*UserDto, error)
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, input DeleteUserInput) error
+	Restore(ctx context.Context, id int64) (*UserDto, error)
+	// History lists the audited changes to a user, newest first. It keeps
+	// working after the user is deleted or purged.
+	History(ctx context.Context, id int64, input HistoryInput) (*HistoryDto, error)
+	// Import creates up to MaxImportBatch users at once. Rows with a taken
+	// email fail individually with ErrEmailTaken; the error return is for
+	// failures that stopped the whole batch.
+	Import(ctx context.Context, rows []CreateUserInput) ([]ImportResult, error)
+	// Export walks every user matching input's filters and sort order,
+	// handing them to fn ExportBatchSize at a time. Paging fields are
+	// ignored. Returning an error from fn stops the walk with that error.
+	Export(ctx context.Context, input ListUsersInput, fn func([]UserDto) error) error
 }
 
 type service struct {
-	repo   dom.Repository
-	cache  cache.UserCache
-	tx     db.Transactor // optional, for multi-entity transactions
-	events Events
-	logger logging.Logger
+	repo    dom.Repository
+	cache   cache.UserCache
+	tx      db.Transactor    // optional; without it events aren't atomic with writes
+	events  Events           // written in the same transaction as the change
+	audit   audit.Repository // optional; also written in that transaction
+	cursors *pagination.CursorCodec
+	logger  logging.Logger
 }
 
-func (s *service) List(ctx context.Context, input ListUsersInput) ([]UserDto, error) {
-	filter := dom.ListFilter{
-		Limit:  input.Limit,
-		Offset: input.Offset,
+func (s *service) List(ctx context.Context, input ListUsersInput) (*UserListDto, error) {
+	filter := listFilter(input)
+
+	// Clamp paging so callers can't ask for unbounded pages.
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+	if filter.Limit > MaxListLimit {
+		filter.Limit = MaxListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	if input.Cursor != "" {
+		if err := s.applyCursor(&filter, input.Cursor); err != nil {
+			return nil, err
+		}
 	}
 
+	// Fetch one extra row to find out whether there is a next page.
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+
 	users, err := s.repo.List(ctx, filter)
 	if err != nil {
 		s.logger.Error("failed to list users", "error", err)
 		return nil, fmt.Errorf("list users: %w", err)
 	}
+	filter.Limit = pageSize
+
+	page := &UserListDto{
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+
+	if len(users) > pageSize {
+		users = users[:pageSize]
+		next, err := s.encodeCursor(filter, users[len(users)-1])
+		if err != nil {
+			return nil, fmt.Errorf("encode cursor: %w", err)
+		}
+		page.NextCursor = next
+	}
+	page.Items = toDTOs(users)
 
-	return toDTOs(users), nil
+	// Counting is skipped for keyset paging: it's what sync jobs use to walk
+	// large tables, and a full COUNT(*) per page defeats the point.
+	if filter.After == nil {
+		total, err := s.repo.Count(ctx, filter)
+		if err != nil {
+			s.logger.Error("failed to count users", "error", err)
+			return nil, fmt.Errorf("count users: %w", err)
+		}
+		page.Total = &total
+	}
+
+	return page, nil
 }
 
 func (s *service) GetById(ctx context.Context, id int64) (*UserDto, error) {
@@ -55,8 +116,9 @@ func (s *service) GetById(ctx context.Context, id int64) (*UserDto, error) {
 		s.logger.Error("failed to get user from cache", "error", err, "id", id)
 	}
 
-	// 2) Fallback to DB
-	u, err := s.repo.GetById(ctx, id)
+	// 2) Fallback to DB. The row is cached for the full TTL, so it must not
+	// come from a replica that may still be behind the last write.
+	u, err := s.repo.GetById(db.WithPrimary(ctx), id)
 	if err != nil {
 		return nil, err
 	}
@@ -81,13 +143,35 @@ func (s *service) Create(ctx context.Context, input CreateUserInput) (*UserDto,
 		Name:  input.Name,
 	}
 
-	if err := s.repo.Create(ctx, u); err != nil {
+	var dto *UserDto
+
+	// The unique index on email is the real guarantee; the pre-check just
+	// gives callers a clean conflict without depending on driver errors.
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		if err := s.ensureEmailFree(ctx, u.Email); err != nil {
+			return err
+		}
+		if err := s.repo.Create(ctx, u); err != nil {
+			return err
+		}
+		if err := writeAudit(ctx, s.audit, audit.ActionCreated, u.ID, nil, u); err != nil {
+			return err
+		}
+
+		dto = toDTO(u)
+		if err := s.events.UserCreated(ctx, dto); err != nil {
+			return fmt.Errorf("UserCreated event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, dom.ErrEmailTaken) {
+			return nil, err
+		}
 		s.logger.Error("failed to create user", "error", err, "email", input.Email)
 		return nil, fmt.Errorf("create user: %w", err)
 	}
 
-	dto := toDTO(u)
-
 	// Cache
 	if data, err := json.Marshal(dto); err == nil {
 		if err := s.cache.Set(ctx, dto.Id, data, defaultUserCacheTTL); err != nil {
@@ -97,31 +181,64 @@ func (s *service) Create(ctx context.Context, input CreateUserInput) (*UserDto,
 		s.logger.Error("failed to marshal user for cache after create", "error", err, "id", dto.Id)
 	}
 
-	// Events (unchanged)
-	if err := s.events.UserCreated(ctx, dto); err != nil {
-		s.logger.Error("failed to publish UserCreated event", "error", err, "id", dto.Id)
-	}
-
 	return dto, nil
 }
 
 func (s *service) Update(ctx context.Context, input UpdateUserInput) (*UserDto, error) {
-	u, err := s.repo.GetById(ctx, input.ID)
-	if err != nil {
-		return nil, err
-	}
+	var dto *UserDto
 
-	if input.Name != nil {
-		u.Name = *input.Name
-	}
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		u, err := s.repo.GetById(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		before := *u
+
+		if input.ExpectedVersion != nil && *input.ExpectedVersion != u.Version {
+			return dom.ErrStaleVersion
+		}
 
-	if err := s.repo.Update(ctx, u); err != nil {
+		if input.Email != nil && *input.Email != u.Email {
+			if err := s.ensureEmailFree(ctx, *input.Email); err != nil {
+				return err
+			}
+			u.Email = *input.Email
+		}
+		if input.Name != nil {
+			u.Name = *input.Name
+		}
+
+		if err := s.repo.Update(ctx, u); err != nil {
+			return err
+		}
+		if err := writeAudit(ctx, s.audit, audit.ActionUpdated, u.ID, &before, u); err != nil {
+			return err
+		}
+
+		dto = toDTO(u)
+		if err := s.events.UserUpdated(ctx, dto); err != nil {
+			return fmt.Errorf("UserUpdated event: %w", err)
+		}
+		if dto.Email != before.Email {
+			if err := s.events.UserEmailChanged(ctx, dto.Id, before.Email, dto.Email); err != nil {
+				return fmt.Errorf("UserEmailChanged event: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, dom.ErrStaleVersion) && input.ExpectedVersion == nil {
+			// The caller didn't ask for a conditional update; the row just
+			// changed between our read and write.
+			return nil, errConcurrentUpdate
+		}
+		if errors.Is(err, dom.ErrNotFound) || errors.Is(err, dom.ErrEmailTaken) || errors.Is(err, dom.ErrStaleVersion) {
+			return nil, err
+		}
 		s.logger.Error("failed to update user", "error", err, "id", input.ID)
 		return nil, fmt.Errorf("update user: %w", err)
 	}
 
-	dto := toDTO(u)
-
 	// Update cache
 	if data, err := json.Marshal(dto); err == nil {
 		if err := s.cache.Set(ctx, dto.Id, data, defaultUserCacheTTL); err != nil {
@@ -131,43 +248,144 @@ func (s *service) Update(ctx context.Context, input UpdateUserInput) (*UserDto,
 		s.logger.Error("failed to marshal user for cache after update", "error", err, "id", dto.Id)
 	}
 
-	if err := s.events.UserUpdated(ctx, dto); err != nil {
-		s.logger.Error("failed to publish UserUpdated event", "error", err, "id", dto.Id)
-	}
-
 	return dto, nil
 }
 
-func (s *service) Delete(ctx context.Context, id int64) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return err
+func (s *service) Delete(ctx context.Context, input DeleteUserInput) error {
+	id := input.ID
+	expected := dom.AnyVersion
+	if input.ExpectedVersion != nil {
+		expected = *input.ExpectedVersion
+	}
+
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Delete(ctx, id, expected); err != nil {
+			return err
+		}
+		if err := writeAudit(ctx, s.audit, audit.ActionDeleted, id, before, nil); err != nil {
+			return err
+		}
+		if err := s.events.UserDeleted(ctx, id, false); err != nil {
+			return fmt.Errorf("UserDeleted event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, dom.ErrNotFound) || errors.Is(err, dom.ErrStaleVersion) {
+			return err
+		}
+		s.logger.Error("failed to delete user", "error", err, "id", id)
+		return fmt.Errorf("delete user: %w", err)
 	}
 
 	if err := s.cache.Delete(ctx, id); err != nil {
 		s.logger.Error("failed to delete user cache after delete", "error", err, "id", id)
 	}
 
-	if err := s.events.UserDeleted(ctx, id); err != nil {
-		s.logger.Error("failed to publish UserDeleted event", "error", err, "id", id)
+	return nil
+}
+
+func (s *service) Restore(ctx context.Context, id int64) (*UserDto, error) {
+	var dto *UserDto
+
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		u, err := s.repo.Restore(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := writeAudit(ctx, s.audit, audit.ActionRestored, id, nil, u); err != nil {
+			return err
+		}
+
+		dto = toDTO(u)
+		if err := s.events.UserRestored(ctx, dto); err != nil {
+			return fmt.Errorf("UserRestored event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, dom.ErrNotFound) {
+			return nil, err
+		}
+		s.logger.Error("failed to restore user", "error", err, "id", id)
+		return nil, fmt.Errorf("restore user: %w", err)
 	}
 
-	return nil
+	if data, err := json.Marshal(dto); err == nil {
+		if err := s.cache.Set(ctx, dto.Id, data, defaultUserCacheTTL); err != nil {
+			s.logger.Error("failed to set user cache after restore", "error", err, "id", dto.Id)
+		}
+	} else {
+		s.logger.Error("failed to marshal user for cache after restore", "error", err, "id", dto.Id)
+	}
+
+	return dto, nil
 }
 
 const defaultUserCacheTTL = 5 * time.Minute
 
+// listFilter maps the filter and sort fields of input; paging is left to
+// the caller.
+func listFilter(input ListUsersInput) dom.ListFilter {
+	filter := dom.ListFilter{
+		Limit:         input.Limit,
+		Offset:        input.Offset,
+		SortBy:        dom.SortField(input.SortBy),
+		SortDesc:      input.SortDesc,
+		Email:         input.Email,
+		NameContains:  input.NameContains,
+		CreatedAfter:  input.CreatedAfter,
+		CreatedBefore: input.CreatedBefore,
+
+		IncludeDeleted: input.IncludeDeleted,
+	}
+	if !filter.SortBy.Valid() {
+		filter.SortBy = dom.SortByID
+	}
+	return filter
+}
+
+// inTx runs fn inside a transaction when a Transactor is configured.
+func (s *service) inTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.tx == nil {
+		return fn(ctx)
+	}
+	return s.tx.WithTx(ctx, fn)
+}
+
+// ensureEmailFree returns dom.ErrEmailTaken if a user with email exists.
+// Soft-deleted users count: they keep their email until purged.
+func (s *service) ensureEmailFree(ctx context.Context, email string) error {
+	taken, err := s.repo.TakenEmails(ctx, []string{email})
+	if err != nil {
+		return fmt.Errorf("check email: %w", err)
+	}
+	if len(taken) > 0 {
+		return dom.ErrEmailTaken
+	}
+	return nil
+}
+
 func NewService(
 	repo dom.Repository,
 	cache cache.UserCache,
 	tx db.Transactor,
 	events Events,
+	auditLog audit.Repository,
+	cursors *pagination.CursorCodec,
 	logger logging.Logger,
 ) Service {
 	return &service{
-		repo:   repo,
-		cache:  cache,
-		tx:     tx,
-		events: events,
-		logger: logger.With("component", "user_service"),
+		repo:    repo,
+		cache:   cache,
+		tx:      tx,
+		events:  events,
+		audit:   auditLog,
+		cursors: cursors,
+		logger:  logger.With("component", "user_service"),
 	}
 }
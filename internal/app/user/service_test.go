@@ -0,0 +1,58 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appuser "kabsa/internal/app/user"
+	"kabsa/internal/db/dbtest"
+	"kabsa/internal/db/repository"
+	dom "kabsa/internal/domain/user"
+	"kabsa/internal/logging"
+	"kabsa/internal/pagination"
+)
+
+// noWrites fails every write, so a conflict must come from the pre-check
+// rather than the unique index.
+type noWrites struct{ dom.Repository }
+
+var errWrite = errors.New("write attempted")
+
+func (noWrites) Create(context.Context, *dom.User) error { return errWrite }
+func (noWrites) Update(context.Context, *dom.User) error { return errWrite }
+
+func TestService_SoftDeletedUserKeepsEmail(t *testing.T) {
+	client := dbtest.NewClient(t)
+	ctx := context.Background()
+	repo := repository.NewUserRepository(client, logging.NewNop())
+	auditLog := repository.NewAuditRepository(client, logging.NewNop())
+	codec, err := pagination.NewCursorCodec("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := appuser.NewService(repo, missCache{}, client, appuser.NoopEvents{}, auditLog, codec, logging.NewNop())
+
+	gone, err := svc.Create(ctx, appuser.CreateUserInput{Email: "gone@example.com", Name: "Gone"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Delete(ctx, appuser.DeleteUserInput{ID: gone.Id}); err != nil {
+		t.Fatal(err)
+	}
+	other, err := svc.Create(ctx, appuser.CreateUserInput{Email: "other@example.com", Name: "Other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc = appuser.NewService(noWrites{repo}, missCache{}, client, appuser.NoopEvents{}, auditLog, codec, logging.NewNop())
+
+	_, err = svc.Create(ctx, appuser.CreateUserInput{Email: gone.Email, Name: "New"})
+	if !errors.Is(err, dom.ErrEmailTaken) {
+		t.Errorf("create: err = %v, want ErrEmailTaken", err)
+	}
+	_, err = svc.Update(ctx, appuser.UpdateUserInput{ID: other.Id, Email: &gone.Email})
+	if !errors.Is(err, dom.ErrEmailTaken) {
+		t.Errorf("update: err = %v, want ErrEmailTaken", err)
+	}
+}
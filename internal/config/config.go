@@ -1,10 +1,33 @@
 ﻿package config
 
-import "strconv"
+import (
+	"strconv"
+	"time"
+)
 
 type HTTPConfig struct {
 	Host string `env:"HOST" envDefault:"0.0.0.0"`
 	Port int    `env:"PORT" envDefault:"8080"`
+	// Reject PUT/PATCH/DELETE without If-Match (428) instead of applying them unconditionally.
+	RequireIfMatch bool `env:"REQUIRE_IF_MATCH" envDefault:"false"`
+	// Allow include_deleted on listings and POST /users/{id}/restore. There
+	// is no per-caller authorization, so only enable it where every client
+	// may see soft-deleted users.
+	ExposeDeleted bool `env:"EXPOSE_DELETED" envDefault:"false"`
+	// Request header naming who is making the change, recorded in the audit
+	// log. Set by the gateway after authentication.
+	ActorHeader string `env:"ACTOR_HEADER" envDefault:"X-Actor"`
+	// Peers (IPs or CIDRs) trusted to set ActorHeader. From anyone else the
+	// actor is recorded with audit.UnverifiedActorPrefix.
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
+	// Deadline for ordinary requests.
+	RequestTimeout time.Duration `env:"REQUEST_TIMEOUT" envDefault:"60s"`
+	// Deadline for streaming endpoints (user export and import).
+	StreamTimeout time.Duration `env:"STREAM_TIMEOUT" envDefault:"30m"`
+	// Most operations accepted by POST /users:batch.
+	MaxBatchSize int `env:"MAX_BATCH_SIZE" envDefault:"100"`
+	// Largest body accepted by POST /users:import.
+	MaxImportBytes int64 `env:"MAX_IMPORT_BYTES" envDefault:"67108864"`
 }
 
 type PostgresConfig struct {
@@ -17,6 +40,27 @@ type PostgresConfig struct {
 	Password string `env:"PASSWORD"`
 	DBName   string `env:"DBNAME"`
 	SSLMode  string `env:"SSLMODE" envDefault:"disable"`
+
+	Pool PoolConfig `envPrefix:"POOL_"`
+
+	// Optional read replicas (comma-separated DSNs). Reads outside
+	// transactions are spread over the healthy ones; see db.Client.ReaderFor.
+	// Health is re-checked every ReplicaCheckInterval; 0 or less checks only
+	// at startup.
+	ReplicaDSNs          []string      `env:"REPLICA_DSNS" envSeparator:","`
+	ReplicaCheckInterval time.Duration `env:"REPLICA_CHECK_INTERVAL" envDefault:"5s"`
+
+	// Statements taking at least this long are logged (0 disables).
+	SlowQueryThreshold time.Duration `env:"SLOW_QUERY_THRESHOLD" envDefault:"200ms"`
+}
+
+// PoolConfig sizes the database/sql connection pool. Keep
+// MaxOpenConns × replicas below the server's max_connections.
+type PoolConfig struct {
+	MaxOpenConns    int           `env:"MAX_OPEN_CONNS" envDefault:"25"`
+	MaxIdleConns    int           `env:"MAX_IDLE_CONNS" envDefault:"25"`
+	ConnMaxLifetime time.Duration `env:"CONN_MAX_LIFETIME" envDefault:"5m"`
+	ConnMaxIdleTime time.Duration `env:"CONN_MAX_IDLE_TIME" envDefault:"1m"`
 }
 
 func (c PostgresConfig) EffectiveDSN() string {
@@ -36,11 +80,68 @@ type RedisConfig struct {
 }
 
 type KafkaConfig struct {
-	Enabled     bool     `mapstructure:"enabled" yaml:"enabled"`
-	Brokers     []string `mapstructure:"brokers" yaml:"brokers"`
-	ClientID    string   `mapstructure:"client_id" yaml:"client_id"`
-	GroupID     string   `mapstructure:"group_id" yaml:"group_id"`
-	TopicPrefix string   `mapstructure:"topic_prefix" yaml:"topic_prefix"`
+	Enabled     bool     `env:"ENABLED" mapstructure:"enabled" yaml:"enabled"`
+	Brokers     []string `env:"BROKERS" envSeparator:"," mapstructure:"brokers" yaml:"brokers"`
+	ClientID    string   `env:"CLIENT_ID" mapstructure:"client_id" yaml:"client_id"`
+	GroupID     string   `env:"GROUP_ID" mapstructure:"group_id" yaml:"group_id"`
+	TopicPrefix string   `env:"TOPIC_PREFIX" mapstructure:"topic_prefix" yaml:"topic_prefix"`
+
+	TLS  KafkaTLSConfig  `envPrefix:"TLS_" mapstructure:"tls" yaml:"tls"`
+	SASL KafkaSASLConfig `envPrefix:"SASL_" mapstructure:"sasl" yaml:"sasl"`
+
+	// A failing message is retried with exponential backoff until it has
+	// been tried RetryMaxAttempts times, then moved to <topic>.dlq.
+	RetryMaxAttempts     int           `env:"RETRY_MAX_ATTEMPTS" envDefault:"5" mapstructure:"retry_max_attempts" yaml:"retry_max_attempts"`
+	RetryInitialInterval time.Duration `env:"RETRY_INITIAL_INTERVAL" envDefault:"500ms" mapstructure:"retry_initial_interval" yaml:"retry_initial_interval"`
+	RetryMaxInterval     time.Duration `env:"RETRY_MAX_INTERVAL" envDefault:"30s" mapstructure:"retry_max_interval" yaml:"retry_max_interval"`
+}
+
+// KafkaTLSConfig secures broker connections. Without CAFile the system
+// roots are trusted; CertFile and KeyFile enable client authentication.
+type KafkaTLSConfig struct {
+	Enabled  bool   `env:"ENABLED" mapstructure:"enabled" yaml:"enabled"`
+	CAFile   string `env:"CA_FILE" mapstructure:"ca_file" yaml:"ca_file"`
+	CertFile string `env:"CERT_FILE" mapstructure:"cert_file" yaml:"cert_file"`
+	KeyFile  string `env:"KEY_FILE" mapstructure:"key_file" yaml:"key_file"`
+	// Accept any broker certificate. Local development only.
+	InsecureSkipVerify bool `env:"INSECURE_SKIP_VERIFY" mapstructure:"insecure_skip_verify" yaml:"insecure_skip_verify"`
+}
+
+// KafkaSASLConfig authenticates to the brokers. Mechanism is one of PLAIN,
+// SCRAM-SHA-256 or SCRAM-SHA-512; empty disables SASL.
+type KafkaSASLConfig struct {
+	Mechanism string `env:"MECHANISM" mapstructure:"mechanism" yaml:"mechanism"`
+	Username  string `env:"USERNAME" mapstructure:"username" yaml:"username"`
+	Password  string `env:"PASSWORD" mapstructure:"password" yaml:"password"`
+}
+
+type UsersConfig struct {
+	// How long soft-deleted users are kept before the purge job removes them.
+	// Zero disables purging.
+	SoftDeleteRetention time.Duration `env:"SOFT_DELETE_RETENTION" envDefault:"720h"`
+	PurgeInterval       time.Duration `env:"PURGE_INTERVAL" envDefault:"1h"`
+}
+
+// OutboxConfig tunes the relay that moves outbox rows to Kafka.
+type OutboxConfig struct {
+	PollInterval time.Duration `env:"POLL_INTERVAL" envDefault:"500ms"`
+	BatchSize    int           `env:"BATCH_SIZE" envDefault:"100"`
+	MaxBackoff   time.Duration `env:"MAX_BACKOFF" envDefault:"5m"`
+	// Published rows are deleted after this long.
+	Retention time.Duration `env:"RETENTION" envDefault:"24h"`
+}
+
+// InboxConfig tunes the consumer-side dedup records.
+type InboxConfig struct {
+	// Processed message IDs are kept this long. It must outlast any
+	// redelivery, so at least the retention of the consumed topics.
+	Retention time.Duration `env:"RETENTION" envDefault:"168h"`
+}
+
+type PaginationConfig struct {
+	// HMAC key for signing list cursors. Must be shared by all replicas;
+	// when empty a random key is generated at startup.
+	CursorSecret string `env:"CURSOR_SECRET"`
 }
 
 type SupplierConfig struct {
@@ -64,6 +165,10 @@ type Config struct {
 	Postgres      PostgresConfig      `envPrefix:"PG_"`
 	Redis         RedisConfig         `envPrefix:"REDIS_"`
 	Kafka         KafkaConfig         `envPrefix:"KAFKA_"`
+	Outbox        OutboxConfig        `envPrefix:"OUTBOX_"`
+	Inbox         InboxConfig         `envPrefix:"INBOX_"`
+	Pagination    PaginationConfig    `envPrefix:"PAGINATION_"`
+	Users         UsersConfig         `envPrefix:"USERS_"`
 	Supplier      SupplierConfig      `envPrefix:"SUPPLIER_"`
 	Observability ObservabilityConfig `envPrefix:"OTEL_"`
 }
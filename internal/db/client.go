@@ -4,10 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
 
 	"kabsa/ent"
+	_ "kabsa/ent/runtime" // schema hooks and interceptors (soft delete)
 	"kabsa/internal/config"
 	"kabsa/internal/logging"
 
@@ -15,9 +20,17 @@ import (
 )
 
 type Client struct {
-	ent    *ent.Client
-	db     *sql.DB
-	logger logging.Logger
+	ent     *ent.Client
+	db      *sql.DB
+	dialect string
+	metrics *metrics
+	slow    time.Duration // slow-query log threshold, see WithSlowQueryThreshold
+	logger  logging.Logger
+
+	replicas    []*replica
+	replicaMu   sync.RWMutex
+	nextReplica atomic.Uint64
+	stopWatch   chan struct{} // closed by Close; nil without replicas
 }
 
 // NewClient creates an Ent client backed by database/sql using the pgx driver.
@@ -25,9 +38,9 @@ func NewClient(ctx context.Context, cfg config.PostgresConfig, logger logging.Lo
 	dsn := cfg.EffectiveDSN()
 
 	// database/sql connection pool using pgx
-	dbStd, err := sql.Open("pgx", dsn)
+	dbStd, err := openPool(dsn, cfg.Pool)
 	if err != nil {
-		return nil, fmt.Errorf("sql.Open: %w", err)
+		return nil, err
 	}
 
 	// Verify connectivity
@@ -36,15 +49,76 @@ func NewClient(ctx context.Context, cfg config.PostgresConfig, logger logging.Lo
 		return nil, fmt.Errorf("db ping: %w", err)
 	}
 
-	// Ent driver from *sql.DB
-	drv := entsql.OpenDB("pgx", dbStd)
-	entClient := ent.NewClient(ent.Driver(drv))
+	c := NewClientFromDB(dbStd, dialect.Postgres, logger, WithSlowQueryThreshold(cfg.SlowQueryThreshold))
 
-	return &Client{
-		ent:    entClient,
-		db:     dbStd,
-		logger: logger.With("component", "db_client"),
-	}, nil
+	// Replicas that are down at startup are only marked unhealthy: the
+	// primary can serve reads until they come back.
+	for i, replicaDSN := range cfg.ReplicaDSNs {
+		pool, err := openPool(replicaDSN, cfg.Pool)
+		if err != nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("replica %d: %w", i, err)
+		}
+		c.addReplica(replicaName(i, replicaDSN), pool)
+	}
+	if len(c.replicas) > 0 {
+		c.checkReplicas(ctx)
+		if cfg.ReplicaCheckInterval > 0 {
+			c.watchReplicas(cfg.ReplicaCheckInterval)
+		} else {
+			c.logger.Info("db replica health checks disabled, replica state is fixed at startup")
+		}
+	}
+
+	return c, nil
+}
+
+func openPool(dsn string, cfg config.PoolConfig) (*sql.DB, error) {
+	pool, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+
+	pool.SetMaxOpenConns(cfg.MaxOpenConns)
+	pool.SetMaxIdleConns(cfg.MaxIdleConns)
+	pool.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	pool.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return pool, nil
+}
+
+// ClientOption configures NewClientFromDB.
+type ClientOption func(*Client)
+
+// WithSlowQueryThreshold logs statements that take at least d (0 disables).
+func WithSlowQueryThreshold(d time.Duration) ClientOption {
+	return func(c *Client) { c.slow = d }
+}
+
+// NewClientFromDB wraps an already opened pool. sqlDialect is the Ent dialect
+// (dialect.Postgres, dialect.SQLite, ...), not the database/sql driver name:
+// Ent uses it to pick placeholder and quoting syntax.
+func NewClientFromDB(dbStd *sql.DB, sqlDialect string, logger logging.Logger, opts ...ClientOption) *Client {
+	c := &Client{
+		db:      dbStd,
+		dialect: sqlDialect,
+		metrics: newMetrics(dbStd, logger),
+		logger:  logger.With("component", "db_client"),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.ent = c.newEnt("primary", dbStd)
+	return c
+}
+
+// newEnt builds an Ent client on pool whose statements are traced (see
+// queryObserver); pool names the pool in spans and logs.
+func (c *Client) newEnt(pool string, db *sql.DB) *ent.Client {
+	drv := &tracedDriver{
+		Driver: entsql.OpenDB(c.dialect, db),
+		obs:    newQueryObserver(c.dialect, pool, c.slow, c.logger),
+	}
+	return ent.NewClient(ent.Driver(drv))
 }
 
 // Ent returns the underlying Ent client.
@@ -52,8 +126,21 @@ func (c *Client) Ent() *ent.Client {
 	return c.ent
 }
 
-// Close closes both the Ent client and the underlying DB pool.
+// EntFor returns the client of the transaction WithTx stored in ctx, or the
+// root client outside a transaction. Repositories use it so their writes
+// join whatever transaction the caller opened.
+func (c *Client) EntFor(ctx context.Context) *ent.Client {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.Client()
+	}
+	return c.ent
+}
+
+// Close closes both the Ent client and the underlying DB pool, and any
+// replicas.
 func (c *Client) Close() error {
+	c.metrics.close()
+	c.closeReplicas()
 	if err := c.ent.Close(); err != nil {
 		_ = c.db.Close()
 		return err
@@ -0,0 +1,43 @@
+// Package dbtest sets up throwaway databases for tests.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	_ "github.com/mattn/go-sqlite3" // sqlite driver
+
+	"kabsa/internal/db"
+	"kabsa/internal/logging"
+)
+
+// OpenSQLite returns an empty in-memory sqlite database private to t; name
+// tells apart several databases in one test. It is closed when t ends.
+func OpenSQLite(t testing.TB, name string) *sql.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s-%s?mode=memory&cache=shared&_fk=1", t.Name(), name)
+	sqlDB, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return sqlDB
+}
+
+// NewClient returns a db.Client over a fresh sqlite database with the Ent
+// schema created, closed when t ends.
+func NewClient(t testing.TB) *db.Client {
+	t.Helper()
+
+	client := db.NewClientFromDB(OpenSQLite(t, "primary"), dialect.SQLite, logging.NewNop())
+	t.Cleanup(func() { _ = client.Close() })
+
+	if err := client.Ent().Schema.Create(context.Background()); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	return client
+}
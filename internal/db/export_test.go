@@ -0,0 +1,20 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"kabsa/ent"
+)
+
+// Hooks for the external db_test package.
+
+const SQLStateSerializationFailure = sqlStateSerializationFailure
+
+// AddReplica registers pool as a replica and returns its Ent client.
+func (c *Client) AddReplica(name string, pool *sql.DB) *ent.Client {
+	c.addReplica(name, pool)
+	return c.replicas[len(c.replicas)-1].ent
+}
+
+func (c *Client) CheckReplicas(ctx context.Context) { c.checkReplicas(ctx) }
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"entgo.io/ent/dialect"
+
+	"kabsa/internal/logging"
+)
+
+// Lock is a held Postgres session-level advisory lock. It lives exactly as
+// long as the connection reserved for it, which the server can drop at any
+// time (restart, failover, idle timeout); long-running holders should call
+// Held before each unit of work that relies on it.
+type Lock struct {
+	conn   *sql.Conn // nil for dialects without advisory locks
+	key    int64
+	logger logging.Logger
+}
+
+// Held reports whether the lock is still held. It queries pg_locks on the
+// lock's own connection, so a dead connection shows up as an error.
+func (l *Lock) Held(ctx context.Context) (bool, error) {
+	if l.conn == nil {
+		return true, nil
+	}
+
+	// A bigint key is stored split into classid (high half) and objid (low
+	// half), with objsubid 1.
+	var held bool
+	err := l.conn.QueryRowContext(ctx, `SELECT EXISTS (
+  SELECT 1 FROM pg_locks
+  WHERE locktype = 'advisory' AND granted AND pid = pg_backend_pid()
+    AND classid = $1::bigint::oid AND objid = $2::bigint::oid AND objsubid = 1)`,
+		int64(uint64(l.key)>>32), int64(uint32(l.key))).Scan(&held)
+	if err != nil {
+		return false, fmt.Errorf("check advisory lock: %w", err)
+	}
+	return held, nil
+}
+
+// Release unlocks and hands the connection back to the pool.
+func (l *Lock) Release() {
+	if l.conn == nil {
+		return
+	}
+	// Use a fresh context: the caller's is often already cancelled here.
+	if _, err := l.conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		l.logger.Error("failed to release advisory lock", "error", err, "key", l.key)
+	}
+	_ = l.conn.Close()
+}
+
+// TryAdvisoryLock takes the Postgres session-level advisory lock key on a
+// connection reserved for the caller. When ok is true the lock is held until
+// Release is called (or the connection dies); when false another session owns
+// it. Other dialects have no advisory locks, so there it always succeeds.
+func (c *Client) TryAdvisoryLock(ctx context.Context, key int64) (lock *Lock, ok bool, err error) {
+	if c.dialect != dialect.Postgres {
+		return &Lock{key: key, logger: c.logger}, true, nil
+	}
+
+	conn, err := c.db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("reserve connection: %w", err)
+	}
+
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&ok); err != nil {
+		_ = conn.Close()
+		return nil, false, fmt.Errorf("pg_try_advisory_lock: %w", err)
+	}
+	if !ok {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+
+	return &Lock{conn: conn, key: key, logger: c.logger}, true, nil
+}
+
+// AdvisoryLock is TryAdvisoryLock that waits for the lock instead of giving
+// up, until ctx is done.
+func (c *Client) AdvisoryLock(ctx context.Context, key int64) (*Lock, error) {
+	if c.dialect != dialect.Postgres {
+		return &Lock{key: key, logger: c.logger}, nil
+	}
+
+	conn, err := c.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reserve connection: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("pg_advisory_lock: %w", err)
+	}
+
+	return &Lock{conn: conn, key: key, logger: c.logger}, nil
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"kabsa/internal/logging"
+)
+
+// meterName scopes the database instruments; the provider comes from
+// telemetry.Setup via the global.
+const meterName = "kabsa/internal/db"
+
+type metrics struct {
+	txRetries   metric.Int64Counter
+	txExhausted metric.Int64Counter
+	meter       metric.Meter
+	pools       []metric.Registration // one per observed pool
+	logger      logging.Logger
+}
+
+func newMetrics(pool *sql.DB, logger logging.Logger) *metrics {
+	meter := otel.Meter(meterName)
+
+	retries, err1 := meter.Int64Counter("db.client.tx.retries",
+		metric.WithDescription("Transactions re-run after a serialization failure or deadlock"),
+		metric.WithUnit("{retry}"))
+	exhausted, err2 := meter.Int64Counter("db.client.tx.retries_exhausted",
+		metric.WithDescription("Transactions that still failed after their last retry"),
+		metric.WithUnit("{transaction}"))
+
+	m := &metrics{txRetries: retries, txExhausted: exhausted, meter: meter, logger: logger}
+	if err := errors.Join(err1, err2); err != nil {
+		logger.Error("failed to create db metrics, continuing without them", "error", err)
+		m.txRetries, m.txExhausted = noop.Int64Counter{}, noop.Int64Counter{}
+	}
+
+	m.observePool("primary", pool)
+	return m
+}
+
+// observePool exports the stats of pool, tagged with its name the same way
+// query spans are (db.client.pool).
+func (m *metrics) observePool(name string, pool *sql.DB) {
+	reg, err := registerPoolMetrics(m.meter, name, pool)
+	if err != nil {
+		m.logger.Error("failed to register db pool metrics", "pool", name, "error", err)
+		return
+	}
+	m.pools = append(m.pools, reg)
+}
+
+// registerPoolMetrics exports sql.DBStats, read once per collection.
+func registerPoolMetrics(meter metric.Meter, name string, pool *sql.DB) (metric.Registration, error) {
+	maxOpen, err1 := meter.Int64ObservableGauge("db.client.connection.max",
+		metric.WithDescription("Maximum number of open connections allowed"),
+		metric.WithUnit("{connection}"))
+	count, err2 := meter.Int64ObservableGauge("db.client.connection.count",
+		metric.WithDescription("Open connections by state (idle or used)"),
+		metric.WithUnit("{connection}"))
+	waits, err3 := meter.Int64ObservableCounter("db.client.connection.wait_count",
+		metric.WithDescription("Times a caller waited for a free connection"),
+		metric.WithUnit("{wait}"))
+	waitTime, err4 := meter.Float64ObservableCounter("db.client.connection.wait_time",
+		metric.WithDescription("Total time spent waiting for a free connection"),
+		metric.WithUnit("s"))
+	if err := errors.Join(err1, err2, err3, err4); err != nil {
+		return nil, err
+	}
+
+	poolAttr := attribute.String("db.client.pool", name)
+	all := metric.WithAttributes(poolAttr)
+	idle := metric.WithAttributes(poolAttr, attribute.String("db.client.connection.state", "idle"))
+	used := metric.WithAttributes(poolAttr, attribute.String("db.client.connection.state", "used"))
+
+	return meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		s := pool.Stats()
+		o.ObserveInt64(maxOpen, int64(s.MaxOpenConnections), all)
+		o.ObserveInt64(count, int64(s.Idle), idle)
+		o.ObserveInt64(count, int64(s.InUse), used)
+		o.ObserveInt64(waits, s.WaitCount, all)
+		o.ObserveFloat64(waitTime, s.WaitDuration.Seconds(), all)
+		return nil
+	}, maxOpen, count, waits, waitTime)
+}
+
+func (m *metrics) txRetried(ctx context.Context, sqlState string) {
+	m.txRetries.Add(ctx, 1, metric.WithAttributes(attribute.String("db.response.status_code", sqlState)))
+}
+
+func (m *metrics) txGaveUp(ctx context.Context, sqlState string) {
+	m.txExhausted.Add(ctx, 1, metric.WithAttributes(attribute.String("db.response.status_code", sqlState)))
+}
+
+// close stops observing the pools, which are about to be closed.
+func (m *metrics) close() {
+	for _, reg := range m.pools {
+		if err := reg.Unregister(); err != nil {
+			m.logger.Error("failed to unregister db pool metrics", "error", err)
+		}
+	}
+	m.pools = nil
+}
@@ -0,0 +1,47 @@
+package db_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"kabsa/internal/db/dbtest"
+)
+
+func TestClient_ExportsStatsPerPool(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	prev := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() { otel.SetMeterProvider(prev) })
+
+	c := dbtest.NewClient(t)
+	c.AddReplica("replica-0", dbtest.OpenSQLite(t, "replica"))
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	var pools []string
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "db.client.connection.max" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Gauge[int64]).DataPoints {
+				if v, ok := dp.Attributes.Value("db.client.pool"); ok {
+					pools = append(pools, v.AsString())
+				}
+			}
+		}
+	}
+	sort.Strings(pools)
+
+	if len(pools) != 2 || pools[0] != "primary" || pools[1] != "replica-0" {
+		t.Errorf("pools = %v, want [primary replica-0]", pools)
+	}
+}
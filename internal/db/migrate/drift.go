@@ -0,0 +1,169 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"entgo.io/ent/dialect/sql/schema"
+
+	entmigrate "kabsa/ent/migrate"
+)
+
+// Drift is one difference between what the code expects and what the
+// database or schema_migrations holds.
+type Drift struct {
+	Object  string // table, table.column, index or migration version
+	Problem string
+}
+
+func (d Drift) String() string { return d.Object + ": " + d.Problem }
+
+// liveSchema is the part of the database catalog the drift check compares.
+type liveSchema struct {
+	columns map[string]map[string]liveColumn // table -> column
+	indexes map[string]bool
+}
+
+type liveColumn struct {
+	nullable bool
+}
+
+// Drift compares the database against the Ent schema in ent/migrate and the
+// applied migrations against the files. An empty result means the migrations
+// produce exactly the schema the code was generated for.
+func (m *Migrator) Drift(ctx context.Context) ([]Drift, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	live, err := m.liveSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := migrationDrift(m.migrations, applied)
+	return append(drift, schemaDrift(entmigrate.Tables, live)...), nil
+}
+
+// migrationDrift reports applied migrations whose file changed or vanished.
+func migrationDrift(all []Migration, applied map[int64]appliedRow) []Drift {
+	byVersion := make(map[int64]Migration, len(all))
+	for _, mig := range all {
+		byVersion[mig.Version] = mig
+	}
+
+	versions := make([]int64, 0, len(applied))
+	for v := range applied {
+		versions = append(versions, v)
+	}
+	slices.Sort(versions)
+
+	var drift []Drift
+	for _, v := range versions {
+		row := applied[v]
+		object := fmt.Sprintf("migration %d_%s", v, row.name)
+		mig, ok := byVersion[v]
+		switch {
+		case !ok:
+			drift = append(drift, Drift{object, "applied but no longer in migrations/"})
+		case mig.Checksum != row.checksum:
+			drift = append(drift, Drift{object, "file changed after it was applied"})
+		}
+	}
+	return drift
+}
+
+// schemaDrift reports tables, columns and indexes the Ent schema declares
+// that the database lacks or defines differently, and columns the database
+// has that Ent does not know about.
+func schemaDrift(tables []*schema.Table, live liveSchema) []Drift {
+	var drift []Drift
+	for _, t := range tables {
+		cols, ok := live.columns[t.Name]
+		if !ok {
+			drift = append(drift, Drift{t.Name, "table missing"})
+			continue
+		}
+
+		for _, c := range t.Columns {
+			object := t.Name + "." + c.Name
+			lc, ok := cols[c.Name]
+			switch {
+			case !ok:
+				drift = append(drift, Drift{object, "column missing"})
+			case lc.nullable != c.Nullable:
+				drift = append(drift, Drift{object, fmt.Sprintf("nullable is %t, schema expects %t", lc.nullable, c.Nullable)})
+			}
+			if ok && c.Unique && !live.indexes[t.Name+"_"+c.Name+"_key"] {
+				drift = append(drift, Drift{t.Name + "_" + c.Name + "_key", "unique index missing"})
+			}
+		}
+
+		var extra []string
+		for name := range cols {
+			if !slices.ContainsFunc(t.Columns, func(c *schema.Column) bool { return c.Name == name }) {
+				extra = append(extra, name)
+			}
+		}
+		slices.Sort(extra)
+		for _, name := range extra {
+			drift = append(drift, Drift{t.Name + "." + name, "column not in Ent schema"})
+		}
+
+		for _, idx := range t.Indexes {
+			if !live.indexes[idx.Name] {
+				drift = append(drift, Drift{idx.Name, "index missing"})
+			}
+		}
+	}
+	return drift
+}
+
+func (m *Migrator) liveSchema(ctx context.Context) (liveSchema, error) {
+	live := liveSchema{
+		columns: make(map[string]map[string]liveColumn),
+		indexes: make(map[string]bool),
+	}
+
+	rows, err := m.client.Ent().QueryContext(ctx, `
+		SELECT table_name, column_name, is_nullable = 'YES'
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()`)
+	if err != nil {
+		return live, fmt.Errorf("read columns: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, column string
+		var c liveColumn
+		if err := rows.Scan(&table, &column, &c.nullable); err != nil {
+			return live, fmt.Errorf("scan columns: %w", err)
+		}
+		if live.columns[table] == nil {
+			live.columns[table] = make(map[string]liveColumn)
+		}
+		live.columns[table][column] = c
+	}
+	if err := rows.Err(); err != nil {
+		return live, err
+	}
+
+	idx, err := m.client.Ent().QueryContext(ctx,
+		`SELECT indexname FROM pg_indexes WHERE schemaname = current_schema()`)
+	if err != nil {
+		return live, fmt.Errorf("read indexes: %w", err)
+	}
+	defer idx.Close()
+	for idx.Next() {
+		var name string
+		if err := idx.Scan(&name); err != nil {
+			return live, fmt.Errorf("scan indexes: %w", err)
+		}
+		live.indexes[name] = true
+	}
+	return live, idx.Err()
+}
@@ -0,0 +1,175 @@
+package migrate
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/schema/field"
+
+	"kabsa/migrations"
+)
+
+func TestLoad_PairsUpAndDownFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"2_add_b.sql":         {Data: []byte("B")},
+		"1_create_a.sql":      {Data: []byte("A")},
+		"1_create_a.down.sql": {Data: []byte("drop A")},
+		"README.md":           {Data: []byte("ignored")},
+	}
+
+	list, err := Load(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Version != 1 || list[1].Version != 2 {
+		t.Fatalf("got %+v, want versions 1, 2", list)
+	}
+	if list[0].Name != "create_a" || list[0].Up != "A" || list[0].Down != "drop A" {
+		t.Errorf("migration 1 = %+v", list[0])
+	}
+	if list[1].Down != "" {
+		t.Errorf("migration 2 has down %q, want none", list[1].Down)
+	}
+	if list[0].Checksum == "" || list[0].Checksum == list[1].Checksum {
+		t.Errorf("checksums %q, %q should be set and differ", list[0].Checksum, list[1].Checksum)
+	}
+}
+
+func TestLoad_RejectsDownWithoutUp(t *testing.T) {
+	_, err := Load(fstest.MapFS{"1_a.down.sql": {Data: []byte("x")}})
+	if err == nil {
+		t.Fatal("expected an error for a down file without an up file")
+	}
+}
+
+func TestLoad_EmbeddedMigrationsAreComplete(t *testing.T) {
+	list, err := Load(migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	for _, m := range list {
+		if m.Down == "" {
+			t.Errorf("migration %d_%s has no down file", m.Version, m.Name)
+		}
+	}
+}
+
+func versions(list []Migration) []int64 {
+	var vs []int64
+	for _, m := range list {
+		vs = append(vs, m.Version)
+	}
+	return vs
+}
+
+func TestPlan(t *testing.T) {
+	all := []Migration{
+		{Version: 1, Down: "d1"},
+		{Version: 2, Down: "d2"},
+		{Version: 3, Down: "d3"},
+	}
+	tests := []struct {
+		name     string
+		applied  map[int64]bool
+		target   int64
+		wantUp   []int64
+		wantDown []int64
+	}{
+		{"fresh to latest", nil, Latest, []int64{1, 2, 3}, nil},
+		{"partial to latest", map[int64]bool{1: true}, Latest, []int64{2, 3}, nil},
+		{"up to version", map[int64]bool{1: true}, 2, []int64{2}, nil},
+		{"down to version", map[int64]bool{1: true, 2: true, 3: true}, 1, nil, []int64{3, 2}},
+		{"down to zero", map[int64]bool{1: true, 2: true}, 0, nil, []int64{2, 1}},
+		{"fills a gap", map[int64]bool{1: true, 3: true}, Latest, []int64{2}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up, down, err := plan(all, tt.applied, tt.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(versions(up), tt.wantUp) || !slices.Equal(versions(down), tt.wantDown) {
+				t.Errorf("up %v down %v, want up %v down %v", versions(up), versions(down), tt.wantUp, tt.wantDown)
+			}
+		})
+	}
+}
+
+func TestPlan_Errors(t *testing.T) {
+	all := []Migration{{Version: 1}, {Version: 2, Down: "d2"}}
+
+	if _, _, err := plan(all, nil, 5); err == nil {
+		t.Error("expected an error for an unknown target version")
+	}
+	if _, _, err := plan(all, map[int64]bool{1: true}, 0); err == nil {
+		t.Error("expected an error reverting a migration without a down file")
+	}
+}
+
+func TestMigrationDrift(t *testing.T) {
+	all := []Migration{{Version: 1, Checksum: "a"}, {Version: 2, Checksum: "b"}}
+	applied := map[int64]appliedRow{
+		1: {name: "one", checksum: "a"},
+		2: {name: "two", checksum: "changed"},
+		9: {name: "gone", checksum: "z"},
+	}
+
+	got := migrationDrift(all, applied)
+	if len(got) != 2 {
+		t.Fatalf("got %v, want 2 drifts", got)
+	}
+	if !strings.Contains(got[0].String(), "2_two") || !strings.Contains(got[0].Problem, "changed") {
+		t.Errorf("drift[0] = %s", got[0])
+	}
+	if !strings.Contains(got[1].String(), "9_gone") || !strings.Contains(got[1].Problem, "no longer") {
+		t.Errorf("drift[1] = %s", got[1])
+	}
+}
+
+func TestSchemaDrift(t *testing.T) {
+	cols := []*schema.Column{
+		{Name: "id", Type: field.TypeInt64},
+		{Name: "email", Type: field.TypeString, Unique: true},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
+	}
+	tables := []*schema.Table{
+		{Name: "users", Columns: cols, Indexes: []*schema.Index{{Name: "users_deleted_idx", Columns: cols[2:]}}},
+		{Name: "outbox", Columns: cols[:1]},
+	}
+
+	t.Run("in sync", func(t *testing.T) {
+		live := liveSchema{
+			columns: map[string]map[string]liveColumn{
+				"users":  {"id": {}, "email": {}, "deleted_at": {nullable: true}},
+				"outbox": {"id": {}},
+			},
+			indexes: map[string]bool{"users_email_key": true, "users_deleted_idx": true},
+		}
+		if got := schemaDrift(tables, live); len(got) != 0 {
+			t.Errorf("unexpected drift %v", got)
+		}
+	})
+
+	t.Run("drifted", func(t *testing.T) {
+		live := liveSchema{
+			columns: map[string]map[string]liveColumn{
+				"users": {"id": {}, "email": {}, "deleted_at": {}, "legacy": {}},
+			},
+			indexes: map[string]bool{},
+		}
+		var got []string
+		for _, d := range schemaDrift(tables, live) {
+			got = append(got, d.Object)
+		}
+		want := []string{"users_email_key", "users.deleted_at", "users.legacy", "users_deleted_idx", "outbox"}
+		if !slices.Equal(got, want) {
+			t.Errorf("drift objects %v, want %v", got, want)
+		}
+	})
+}
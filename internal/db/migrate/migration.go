@@ -0,0 +1,115 @@
+// Package migrate applies the versioned SQL files in migrations/ and keeps
+// track of them in the schema_migrations table.
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// Migration is one versioned schema change.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string // empty when there is no .down.sql file
+	Checksum string // of Up; detects files edited after being applied
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+?)(\.down)?\.sql$`)
+
+// Load reads <version>_<name>.sql and <version>_<name>.down.sql files from
+// the root of fsys, sorted by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		m := fileName.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: bad version: %w", e.Name(), err)
+		}
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", e.Name(), err)
+		}
+
+		mig := byVersion[version]
+		if mig == nil {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, mig.Name, m[2])
+		}
+		if m[3] != "" {
+			mig.Down = string(body)
+		} else {
+			mig.Up = string(body)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has a down file but no up file", mig.Version, mig.Name)
+		}
+		sum := sha256.Sum256([]byte(mig.Up))
+		mig.Checksum = hex.EncodeToString(sum[:])
+		list = append(list, *mig)
+	}
+	slices.SortFunc(list, func(a, b Migration) int { return cmpInt64(a.Version, b.Version) })
+	return list, nil
+}
+
+func cmpInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Latest is the target version meaning "everything".
+const Latest int64 = -1
+
+// plan works out what moves the database from applied to target: up
+// migrations to run in order, or down migrations to revert in order.
+// Target 0 reverts everything.
+func plan(all []Migration, applied map[int64]bool, target int64) (up, down []Migration, err error) {
+	if target == Latest && len(all) > 0 {
+		target = all[len(all)-1].Version
+	}
+	if target > 0 && !slices.ContainsFunc(all, func(m Migration) bool { return m.Version == target }) {
+		return nil, nil, fmt.Errorf("no migration with version %d", target)
+	}
+
+	for _, m := range all {
+		if m.Version <= target && !applied[m.Version] {
+			up = append(up, m)
+		}
+	}
+	for i := len(all) - 1; i >= 0; i-- {
+		m := all[i]
+		if m.Version > target && applied[m.Version] {
+			if m.Down == "" {
+				return nil, nil, fmt.Errorf("migration %d_%s has no down file", m.Version, m.Name)
+			}
+			down = append(down, m)
+		}
+	}
+	return up, down, nil
+}
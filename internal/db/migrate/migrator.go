@@ -0,0 +1,251 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"kabsa/internal/db"
+	"kabsa/internal/logging"
+)
+
+// lockKey serializes migrators across pods; see db.Client.AdvisoryLock.
+const lockKey int64 = 0x6d696772617465 // "migrate"
+
+const createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version bigint PRIMARY KEY,
+  name character varying NOT NULL,
+  checksum character varying NOT NULL,
+  applied_at timestamptz NOT NULL DEFAULT now()
+)`
+
+// Migrator applies migrations to the primary database. It only speaks
+// Postgres: the SQL files and the bookkeeping table are written for it.
+type Migrator struct {
+	client     *db.Client
+	migrations []Migration
+	logger     logging.Logger
+}
+
+func New(client *db.Client, fsys fs.FS, logger logging.Logger) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		client:     client,
+		migrations: migrations,
+		logger:     logger.With("component", "migrator"),
+	}, nil
+}
+
+// Status is a migration and whether/when it was applied.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+	Modified  bool // applied with a different checksum than the file's
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	return m.To(ctx, Latest)
+}
+
+// Down reverts the last steps applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.locked(ctx, func(ctx context.Context, applied map[int64]appliedRow) error {
+		var target int64
+		n := 0
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if _, ok := applied[m.migrations[i].Version]; !ok {
+				continue
+			}
+			if n == steps {
+				target = m.migrations[i].Version
+				break
+			}
+			n++
+		}
+		var err error
+		reverted, err = m.migrateTo(ctx, applied, target)
+		return err
+	})
+	return reverted, err
+}
+
+// To applies or reverts migrations until exactly those up to version are
+// applied. Latest applies everything; 0 reverts everything.
+func (m *Migrator) To(ctx context.Context, version int64) ([]Migration, error) {
+	var changed []Migration
+	err := m.locked(ctx, func(ctx context.Context, applied map[int64]appliedRow) error {
+		var err error
+		changed, err = m.migrateTo(ctx, applied, version)
+		return err
+	})
+	return changed, err
+}
+
+// Baseline records every migration up to version as applied without running
+// it, for databases whose schema was created before the migrator existed.
+func (m *Migrator) Baseline(ctx context.Context, version int64) ([]Migration, error) {
+	var marked []Migration
+	err := m.locked(ctx, func(ctx context.Context, applied map[int64]appliedRow) error {
+		up, down, err := plan(m.migrations, appliedSet(applied), version)
+		if err != nil {
+			return err
+		}
+		if len(down) > 0 {
+			return fmt.Errorf("baseline %d is below the applied version", version)
+		}
+		for _, mig := range up {
+			if err := m.record(ctx, mig); err != nil {
+				return err
+			}
+			marked = append(marked, mig)
+		}
+		return nil
+	})
+	return marked, err
+}
+
+// Status lists every known migration, plus applied versions that have no
+// file (which Drift also reports).
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		st := Status{Migration: mig}
+		if row, ok := applied[mig.Version]; ok {
+			at := row.appliedAt
+			st.AppliedAt = &at
+			st.Modified = row.checksum != mig.Checksum
+		}
+		list = append(list, st)
+	}
+	return list, nil
+}
+
+type appliedRow struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+func appliedSet(applied map[int64]appliedRow) map[int64]bool {
+	set := make(map[int64]bool, len(applied))
+	for v := range applied {
+		set[v] = true
+	}
+	return set
+}
+
+// locked runs fn holding the migration lock, with the applied state read
+// after taking it.
+func (m *Migrator) locked(ctx context.Context, fn func(ctx context.Context, applied map[int64]appliedRow) error) error {
+	lock, err := m.client.AdvisoryLock(ctx, lockKey)
+	if err != nil {
+		return fmt.Errorf("take migration lock: %w", err)
+	}
+	defer lock.Release()
+
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	return fn(ctx, applied)
+}
+
+func (m *Migrator) migrateTo(ctx context.Context, applied map[int64]appliedRow, target int64) ([]Migration, error) {
+	up, down, err := plan(m.migrations, appliedSet(applied), target)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, mig := range down {
+		m.logger.Info("reverting migration", "version", mig.Version, "name", mig.Name)
+		if err := m.run(ctx, mig, mig.Down, false); err != nil {
+			return done, err
+		}
+		done = append(done, mig)
+	}
+	for _, mig := range up {
+		m.logger.Info("applying migration", "version", mig.Version, "name", mig.Name)
+		if err := m.run(ctx, mig, mig.Up, true); err != nil {
+			return done, err
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// run executes one migration and its bookkeeping in a single transaction,
+// so a failed migration leaves nothing behind.
+func (m *Migrator) run(ctx context.Context, mig Migration, stmt string, up bool) error {
+	err := m.client.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := m.client.EntFor(ctx).ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+		if up {
+			return m.record(ctx, mig)
+		}
+		_, err := m.client.EntFor(ctx).ExecContext(ctx,
+			`DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+		return err
+	}, db.WithRetries(0))
+	if err != nil {
+		return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+	}
+	return nil
+}
+
+func (m *Migrator) record(ctx context.Context, mig Migration) error {
+	_, err := m.client.EntFor(ctx).ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+		mig.Version, mig.Name, mig.Checksum)
+	if err != nil {
+		return fmt.Errorf("record migration %d: %w", mig.Version, err)
+	}
+	return nil
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	if _, err := m.client.Ent().ExecContext(ctx, createTable); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]appliedRow, error) {
+	rows, err := m.client.Ent().QueryContext(ctx,
+		`SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]appliedRow)
+	for rows.Next() {
+		var (
+			version int64
+			row     appliedRow
+		)
+		if err := rows.Scan(&version, &row.name, &row.checksum, &row.appliedAt); err != nil {
+			return nil, fmt.Errorf("scan schema_migrations: %w", err)
+		}
+		applied[version] = row
+	}
+	return applied, rows.Err()
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
+
+	"kabsa/ent"
+)
+
+// replica is a read-only copy of the primary.
+type replica struct {
+	name    string // for logs; never the DSN, which holds the password
+	db      *sql.DB
+	ent     *ent.Client
+	healthy bool // guarded by Client.replicaMu
+}
+
+type primaryKey struct{}
+
+// WithPrimary returns a ctx under which ReaderFor always picks the primary.
+// Use it to read your own writes, which replicas may not have yet.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func usePrimary(ctx context.Context) bool {
+	forced, _ := ctx.Value(primaryKey{}).(bool)
+	return forced
+}
+
+// ReaderFor returns the client to use for a read-only query. Reads go to a
+// healthy replica, round-robin, except:
+//   - inside WithTx, where they must see the transaction (EntFor);
+//   - under WithPrimary;
+//   - when there are no replicas, or none is healthy.
+func (c *Client) ReaderFor(ctx context.Context) *ent.Client {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.Client()
+	}
+	if usePrimary(ctx) || len(c.replicas) == 0 {
+		return c.ent
+	}
+
+	c.replicaMu.RLock()
+	defer c.replicaMu.RUnlock()
+
+	n := uint64(len(c.replicas))
+	start := c.nextReplica.Add(1)
+	for i := range n {
+		if r := c.replicas[(start+i)%n]; r.healthy {
+			return r.ent
+		}
+	}
+	return c.ent
+}
+
+func (c *Client) addReplica(name string, pool *sql.DB) {
+	c.metrics.observePool(name, pool)
+	c.replicas = append(c.replicas, &replica{
+		name: name,
+		db:   pool,
+		ent:  c.newEnt(name, pool),
+	})
+}
+
+const replicaPingTimeout = 2 * time.Second
+
+// checkReplicas pings every replica and records which ones answer, logging
+// only state changes.
+func (c *Client) checkReplicas(ctx context.Context) {
+	for _, r := range c.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+		err := r.db.PingContext(pingCtx)
+		cancel()
+
+		c.replicaMu.Lock()
+		was := r.healthy
+		r.healthy = err == nil
+		c.replicaMu.Unlock()
+
+		switch {
+		case was && err != nil:
+			c.logger.Error("db replica unhealthy, reading from others", "replica", r.name, "error", err)
+		case !was && err == nil:
+			c.logger.Info("db replica healthy", "replica", r.name)
+		}
+	}
+}
+
+// watchReplicas re-checks replicas every interval until Close. interval
+// must be positive.
+func (c *Client) watchReplicas(interval time.Duration) {
+	c.stopWatch = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stopWatch:
+				return
+			case <-ticker.C:
+				c.checkReplicas(context.Background())
+			}
+		}
+	}()
+}
+
+func (c *Client) closeReplicas() {
+	if c.stopWatch != nil {
+		close(c.stopWatch)
+	}
+	for _, r := range c.replicas {
+		if err := r.ent.Close(); err != nil {
+			c.logger.Error("failed to close db replica", "replica", r.name, "error", err)
+		}
+	}
+}
+
+// replicaName identifies a replica in logs by host when the DSN is a URL.
+func replicaName(i int, dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Host != "" {
+		return fmt.Sprintf("replica-%d(%s)", i, u.Host)
+	}
+	return fmt.Sprintf("replica-%d", i)
+}
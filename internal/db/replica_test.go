@@ -0,0 +1,64 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"kabsa/internal/db"
+	"kabsa/internal/db/dbtest"
+)
+
+func TestClient_ReaderForRoutesReadsToHealthyReplicas(t *testing.T) {
+	ctx := context.Background()
+	c := dbtest.NewClient(t)
+	if err := createUser(ctx, c, "primary@example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second database stands in for the replica; which one answered is
+	// told apart by the user it holds.
+	replicaDB := dbtest.OpenSQLite(t, "replica")
+	replica := c.AddReplica("replica-0", replicaDB)
+	if err := replica.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := replica.User.Create().SetEmail("replica@example.com").SetName("R").Exec(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	readFrom := func(ctx context.Context) string {
+		t.Helper()
+		email, err := c.ReaderFor(ctx).User.Query().Select("email").String(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return email
+	}
+
+	if got := readFrom(ctx); got != "primary@example.com" {
+		t.Errorf("before health check read from %s, want primary", got)
+	}
+
+	c.CheckReplicas(ctx)
+	if got := readFrom(ctx); got != "replica@example.com" {
+		t.Errorf("healthy replica: read from %s, want replica", got)
+	}
+	if got := readFrom(db.WithPrimary(ctx)); got != "primary@example.com" {
+		t.Errorf("WithPrimary: read from %s, want primary", got)
+	}
+	err := c.WithTx(ctx, func(ctx context.Context) error {
+		if got := readFrom(ctx); got != "primary@example.com" {
+			t.Errorf("in tx: read from %s, want primary", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = replicaDB.Close()
+	c.CheckReplicas(ctx)
+	if got := readFrom(ctx); got != "primary@example.com" {
+		t.Errorf("replica down: read from %s, want primary", got)
+	}
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"kabsa/ent"
+	entaudit "kabsa/ent/auditentry"
+	"kabsa/internal/db"
+	"kabsa/internal/domain/audit"
+	"kabsa/internal/logging"
+)
+
+type AuditRepository struct {
+	client *db.Client
+	logger logging.Logger
+}
+
+func NewAuditRepository(client *db.Client, logger logging.Logger) audit.Repository {
+	return &AuditRepository{
+		client: client,
+		logger: logger.With("component", "audit_repo"),
+	}
+}
+
+func (r *AuditRepository) Append(ctx context.Context, e *audit.Entry) error {
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return fmt.Errorf("marshal audit changes: %w", err)
+	}
+
+	created, err := r.client.EntFor(ctx).AuditEntry.
+		Create().
+		SetActor(e.Actor).
+		SetAction(string(e.Action)).
+		SetEntityType(e.EntityType).
+		SetEntityID(e.EntityID).
+		SetChanges(changes).
+		SetRequestID(e.RequestID).
+		SetTraceID(e.TraceID).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("ent.AuditEntry.Create: %w", err)
+	}
+
+	e.ID = created.ID
+	e.CreatedAt = created.CreatedAt
+	return nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, entityType string, entityID int64, filter audit.ListFilter) ([]audit.Entry, error) {
+	q := r.client.ReaderFor(ctx).AuditEntry.
+		Query().
+		Where(entaudit.EntityType(entityType), entaudit.EntityID(entityID))
+	if filter.BeforeID > 0 {
+		q = q.Where(entaudit.IDLT(filter.BeforeID))
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+
+	rows, err := q.Order(ent.Desc(entaudit.FieldID)).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ent.AuditEntry.Query.All: %w", err)
+	}
+
+	entries := make([]audit.Entry, 0, len(rows))
+	for _, row := range rows {
+		e := audit.Entry{
+			ID:         row.ID,
+			Actor:      row.Actor,
+			Action:     audit.Action(row.Action),
+			EntityType: row.EntityType,
+			EntityID:   row.EntityID,
+			RequestID:  row.RequestID,
+			TraceID:    row.TraceID,
+			CreatedAt:  row.CreatedAt,
+		}
+		if err := json.Unmarshal(row.Changes, &e.Changes); err != nil {
+			return nil, fmt.Errorf("unmarshal audit entry %d changes: %w", row.ID, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"kabsa/internal/domain/audit"
+	"kabsa/internal/logging"
+)
+
+func TestAuditRepository_ListsEntityHistoryNewestFirst(t *testing.T) {
+	_, client := newTestRepo(t)
+	repo := NewAuditRepository(client, logging.NewNop())
+	ctx := audit.WithActor(context.Background(), "admin@example.com")
+
+	appendEntry := func(entityID int64, action audit.Action, changes map[string]audit.Change) {
+		t.Helper()
+		e := &audit.Entry{
+			Actor:      audit.ActorFrom(ctx),
+			Action:     action,
+			EntityType: "user",
+			EntityID:   entityID,
+			Changes:    changes,
+		}
+		if err := repo.Append(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+		if e.ID == 0 || e.CreatedAt.IsZero() {
+			t.Fatalf("Append did not set ID/CreatedAt: %+v", e)
+		}
+	}
+
+	appendEntry(1, audit.ActionCreated, audit.Diff(nil, map[string]any{"name": "Old"}))
+	appendEntry(2, audit.ActionCreated, nil)
+	appendEntry(1, audit.ActionUpdated, audit.Diff(map[string]any{"name": "Old"}, map[string]any{"name": "New"}))
+	appendEntry(1, audit.ActionDeleted, nil)
+
+	entries, err := repo.List(ctx, "user", 1, audit.ListFilter{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Action != audit.ActionDeleted || entries[1].Action != audit.ActionUpdated {
+		t.Fatalf("got %+v, want deleted then updated", entries)
+	}
+	if got := entries[1].Changes["name"]; got.From != "Old" || got.To != "New" {
+		t.Errorf("name change = %+v, want Old -> New", got)
+	}
+	if entries[1].Actor != "admin@example.com" {
+		t.Errorf("actor = %q", entries[1].Actor)
+	}
+
+	older, err := repo.List(ctx, "user", 1, audit.ListFilter{Limit: 10, BeforeID: entries[1].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(older) != 1 || older[0].Action != audit.ActionCreated {
+		t.Errorf("before %d: got %+v, want only created", entries[1].ID, older)
+	}
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+	"kabsa/ent"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	pgUniqueViolation = "23505"
+
+	usersEmailKey = "users_email_key"
+)
+
+// isUniqueViolation reports whether err is a unique-constraint failure on the
+// named Postgres constraint. Other drivers don't expose constraint names, so
+// for them any unique failure matches.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == constraint
+	}
+	return ent.IsConstraintError(err) && sqlgraph.IsUniqueConstraintError(err)
+}
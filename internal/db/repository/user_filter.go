@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"kabsa/ent/predicate"
+	entuser "kabsa/ent/user"
+	dom "kabsa/internal/domain/user"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// userPredicates translates the filter part of a ListFilter into Ent predicates.
+func userPredicates(filter dom.ListFilter) []predicate.User {
+	var ps []predicate.User
+
+	if filter.Email != "" {
+		ps = append(ps, entuser.EmailEqualFold(filter.Email))
+	}
+	if filter.NameContains != "" {
+		ps = append(ps, entuser.NameContainsFold(filter.NameContains))
+	}
+	if filter.CreatedAfter != nil {
+		ps = append(ps, entuser.CreatedAtGT(*filter.CreatedAfter))
+	}
+	if filter.CreatedBefore != nil {
+		ps = append(ps, entuser.CreatedAtLT(*filter.CreatedBefore))
+	}
+
+	return ps
+}
+
+// afterCursor selects rows strictly after filter.After in the order produced
+// by userOrder, i.e. (col, id) > (value, id) for ascending sorts.
+func afterCursor(filter dom.ListFilter) predicate.User {
+	cur := filter.After
+	cmp := sql.GT
+	if filter.SortDesc {
+		cmp = sql.LT
+	}
+
+	col := sortColumn(filter.SortBy)
+	if col == entuser.FieldID {
+		return func(s *sql.Selector) {
+			s.Where(cmp(s.C(entuser.FieldID), cur.ID))
+		}
+	}
+
+	return func(s *sql.Selector) {
+		s.Where(sql.Or(
+			cmp(s.C(col), cur.Value),
+			sql.And(
+				sql.EQ(s.C(col), cur.Value),
+				cmp(s.C(entuser.FieldID), cur.ID),
+			),
+		))
+	}
+}
+
+func sortColumn(f dom.SortField) string {
+	switch f {
+	case dom.SortByEmail:
+		return entuser.FieldEmail
+	case dom.SortByName:
+		return entuser.FieldName
+	case dom.SortByCreatedAt:
+		return entuser.FieldCreatedAt
+	default:
+		return entuser.FieldID
+	}
+}
+
+// userOrder returns the ORDER BY terms for a ListFilter. ID is always the last
+// term so that pages are stable when the primary sort column has duplicates.
+func userOrder(filter dom.ListFilter) []entuser.OrderOption {
+	opts := []sql.OrderTermOption{sql.OrderAsc()}
+	if filter.SortDesc {
+		opts = []sql.OrderTermOption{sql.OrderDesc()}
+	}
+
+	switch filter.SortBy {
+	case dom.SortByEmail:
+		return []entuser.OrderOption{entuser.ByEmail(opts...), entuser.ByID(opts...)}
+	case dom.SortByName:
+		return []entuser.OrderOption{entuser.ByName(opts...), entuser.ByID(opts...)}
+	case dom.SortByCreatedAt:
+		return []entuser.OrderOption{entuser.ByCreatedAt(opts...), entuser.ByID(opts...)}
+	default:
+		return []entuser.OrderOption{entuser.ByID(opts...)}
+	}
+}
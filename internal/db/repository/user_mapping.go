@@ -15,6 +15,8 @@ func toDomainUser(e *ent.User) *dom.User {
 		Name:      e.Name,
 		CreatedAt: e.CreatedAt,
 		UpdatedAt: e.UpdatedAt,
+		Version:   e.Version,
+		DeletedAt: e.DeletedAt,
 	}
 }
 
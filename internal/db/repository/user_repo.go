@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"kabsa/ent"
+	"kabsa/ent/schema"
 	entuser "kabsa/ent/user"
 	"kabsa/internal/db"
 	dom "kabsa/internal/domain/user"
 	"kabsa/internal/logging"
+	"time"
 )
 
 type UserRepository struct {
@@ -23,7 +25,7 @@ func NewUserRepository(client *db.Client, logger logging.Logger) dom.Repository
 }
 
 func (r *UserRepository) GetById(ctx context.Context, id int64) (*dom.User, error) {
-	u, err := r.client.Ent().User.Get(ctx, id)
+	u, err := r.client.ReaderFor(ctx).User.Get(ctx, id)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return nil, dom.ErrNotFound
@@ -34,7 +36,7 @@ func (r *UserRepository) GetById(ctx context.Context, id int64) (*dom.User, erro
 }
 
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*dom.User, error) {
-	u, err := r.client.Ent().User.
+	u, err := r.client.ReaderFor(ctx).User.
 		Query().
 		Where(entuser.EmailEQ(email)).
 		Only(ctx)
@@ -48,16 +50,24 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*dom.Use
 }
 
 func (r *UserRepository) List(ctx context.Context, filter dom.ListFilter) ([]dom.User, error) {
-	q := r.client.Ent().User.Query()
+	if filter.IncludeDeleted {
+		ctx = schema.SkipSoftDelete(ctx)
+	}
+
+	q := r.client.ReaderFor(ctx).User.
+		Query().
+		Where(userPredicates(filter)...)
 
 	if filter.Limit > 0 {
 		q = q.Limit(filter.Limit)
 	}
-	if filter.Offset > 0 {
+	if filter.After != nil {
+		q = q.Where(afterCursor(filter))
+	} else if filter.Offset > 0 {
 		q = q.Offset(filter.Offset)
 	}
 
-	users, err := q.Order(ent.Asc(entuser.FieldID)).All(ctx)
+	users, err := q.Order(userOrder(filter)...).All(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("ent.User.Query.All: %w", err)
 	}
@@ -65,14 +75,31 @@ func (r *UserRepository) List(ctx context.Context, filter dom.ListFilter) ([]dom
 	return toDomainUsers(users), nil
 }
 
+func (r *UserRepository) Count(ctx context.Context, filter dom.ListFilter) (int, error) {
+	if filter.IncludeDeleted {
+		ctx = schema.SkipSoftDelete(ctx)
+	}
+
+	n, err := r.client.ReaderFor(ctx).User.
+		Query().
+		Where(userPredicates(filter)...).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ent.User.Query.Count: %w", err)
+	}
+	return n, nil
+}
+
 func (r *UserRepository) Create(ctx context.Context, u *dom.User) error {
-	created, err := r.client.Ent().User.
+	created, err := r.client.EntFor(ctx).User.
 		Create().
 		SetEmail(u.Email).
 		SetName(u.Name).
 		Save(ctx)
 	if err != nil {
-		// handle unique email violation, etc.
+		if isUniqueViolation(err, usersEmailKey) {
+			return dom.ErrEmailTaken
+		}
 		return fmt.Errorf("ent.User.Create: %w", err)
 	}
 
@@ -80,33 +107,162 @@ func (r *UserRepository) Create(ctx context.Context, u *dom.User) error {
 	u.ID = created.ID
 	u.CreatedAt = created.CreatedAt
 	u.UpdatedAt = created.UpdatedAt
+	u.Version = created.Version
 	return nil
 }
 
+func (r *UserRepository) CreateBulk(ctx context.Context, users []*dom.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	client := r.client.EntFor(ctx)
+	builders := make([]*ent.UserCreate, len(users))
+	for i, u := range users {
+		builders[i] = client.User.Create().
+			SetEmail(u.Email).
+			SetName(u.Name)
+	}
+
+	created, err := client.User.CreateBulk(builders...).Save(ctx)
+	if err != nil {
+		if isUniqueViolation(err, usersEmailKey) {
+			return dom.ErrEmailTaken
+		}
+		return fmt.Errorf("ent.User.CreateBulk: %w", err)
+	}
+
+	for i, c := range created {
+		users[i].ID = c.ID
+		users[i].CreatedAt = c.CreatedAt
+		users[i].UpdatedAt = c.UpdatedAt
+		users[i].Version = c.Version
+	}
+	return nil
+}
+
+func (r *UserRepository) TakenEmails(ctx context.Context, emails []string) ([]string, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	ctx = schema.SkipSoftDelete(ctx)
+
+	taken, err := r.client.EntFor(ctx).User.
+		Query().
+		Where(entuser.EmailIn(emails...)).
+		Select(entuser.FieldEmail).
+		Strings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ent.User.Query(emails): %w", err)
+	}
+	return taken, nil
+}
+
 func (r *UserRepository) Update(ctx context.Context, u *dom.User) error {
-	_, err := r.client.Ent().User.
+	updated, err := r.client.EntFor(ctx).User.
 		UpdateOneID(u.ID).
+		Where(entuser.Version(u.Version), entuser.DeletedAtIsNil()).
 		SetEmail(u.Email).
 		SetName(u.Name).
+		AddVersion(1).
 		Save(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
-			return dom.ErrNotFound
+			return r.missingOrStale(ctx, u.ID)
+		}
+		if isUniqueViolation(err, usersEmailKey) {
+			return dom.ErrEmailTaken
 		}
 		return fmt.Errorf("ent.User.UpdateOneID.Save: %w", err)
 	}
+
+	u.Version = updated.Version
+	u.UpdatedAt = updated.UpdatedAt
 	return nil
 }
 
-func (r *UserRepository) Delete(ctx context.Context, id int64) error {
-	err := r.client.Ent().User.
-		DeleteOneID(id).
-		Exec(ctx)
+func (r *UserRepository) Delete(ctx context.Context, id int64, expectedVersion int64) error {
+	q := r.client.EntFor(ctx).User.
+		Update().
+		Where(entuser.ID(id), entuser.DeletedAtIsNil())
+	if expectedVersion != dom.AnyVersion {
+		q = q.Where(entuser.Version(expectedVersion))
+	}
+
+	n, err := q.
+		SetDeletedAt(time.Now()).
+		AddVersion(1).
+		Save(ctx)
 	if err != nil {
-		if ent.IsNotFound(err) {
-			return dom.ErrNotFound
-		}
-		return fmt.Errorf("ent.User.DeleteOneID.Exec: %w", err)
+		return fmt.Errorf("ent.User.Update(soft delete).Save: %w", err)
+	}
+	if n == 0 {
+		return r.missingOrStale(ctx, id)
 	}
 	return nil
 }
+
+func (r *UserRepository) Restore(ctx context.Context, id int64) (*dom.User, error) {
+	ctx = schema.SkipSoftDelete(ctx)
+
+	_, err := r.client.EntFor(ctx).User.
+		Update().
+		Where(entuser.ID(id), entuser.DeletedAtNotNil()).
+		ClearDeletedAt().
+		AddVersion(1).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ent.User.Update(restore).Save: %w", err)
+	}
+
+	// Whether or not a row was restored, the user is live now if it exists.
+	// Read it back from the primary: a replica may not have the update yet.
+	return r.GetById(db.WithPrimary(ctx), id)
+}
+
+func (r *UserRepository) Purge(ctx context.Context, deletedBefore time.Time, limit int) ([]dom.User, error) {
+	ctx = schema.SkipSoftDelete(ctx)
+
+	users, err := r.client.EntFor(ctx).User.
+		Query().
+		Where(entuser.DeletedAtLT(deletedBefore)).
+		Order(ent.Asc(entuser.FieldDeletedAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ent.User.Query.All: %w", err)
+	}
+
+	// Delete one at a time, re-checking deleted_at, so a user restored in the
+	// meantime survives and only rows we actually removed are reported.
+	purged := make([]dom.User, 0, len(users))
+	for _, u := range users {
+		n, err := r.client.EntFor(ctx).User.
+			Delete().
+			Where(entuser.ID(u.ID), entuser.DeletedAtLT(deletedBefore)).
+			Exec(ctx)
+		if err != nil {
+			return purged, fmt.Errorf("ent.User.Delete.Exec: %w", err)
+		}
+		if n > 0 {
+			purged = append(purged, *toDomainUser(u))
+		}
+	}
+	return purged, nil
+}
+
+// missingOrStale tells apart the two reasons a conditional write on id can
+// match no rows.
+func (r *UserRepository) missingOrStale(ctx context.Context, id int64) error {
+	exists, err := r.client.EntFor(ctx).User.
+		Query().
+		Where(entuser.ID(id)).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("ent.User.Query.Exist: %w", err)
+	}
+	if !exists {
+		return dom.ErrNotFound
+	}
+	return dom.ErrStaleVersion
+}
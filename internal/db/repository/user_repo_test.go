@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"kabsa/internal/db"
+	"kabsa/internal/db/dbtest"
+	domcommon "kabsa/internal/domain/common"
+	dom "kabsa/internal/domain/user"
+	"kabsa/internal/logging"
+)
+
+func newTestRepo(t *testing.T) (dom.Repository, *db.Client) {
+	t.Helper()
+
+	client := dbtest.NewClient(t)
+	return NewUserRepository(client, logging.NewNop()), client
+}
+
+func assertNotFound(t *testing.T, err error) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatal("expected not found error, got nil")
+	}
+	if !errors.Is(err, dom.ErrNotFound) {
+		t.Errorf("errors.Is(err, dom.ErrNotFound) = false for %v", err)
+	}
+	if !domcommon.IsNotFound(err) {
+		t.Errorf("domcommon.IsNotFound(err) = false for %v", err)
+	}
+}
+
+func TestUserRepository_MissingUserIsNotFound(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+
+	t.Run("GetById", func(t *testing.T) {
+		_, err := repo.GetById(ctx, 42)
+		assertNotFound(t, err)
+	})
+
+	t.Run("GetByEmail", func(t *testing.T) {
+		_, err := repo.GetByEmail(ctx, "nobody@example.com")
+		assertNotFound(t, err)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		err := repo.Update(ctx, &dom.User{ID: 42, Email: "a@example.com", Name: "Ann"})
+		assertNotFound(t, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		err := repo.Delete(ctx, 42, dom.AnyVersion)
+		assertNotFound(t, err)
+	})
+}
+
+func TestUserRepository_DeletedUserIsNotFound(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+
+	u := &dom.User{Email: "ann@example.com", Name: "Ann"}
+	if err := repo.Create(ctx, u); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := repo.GetById(ctx, u.ID); err != nil {
+		t.Fatalf("get after create: %v", err)
+	}
+
+	if err := repo.Delete(ctx, u.ID, dom.AnyVersion); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	_, err := repo.GetById(ctx, u.ID)
+	assertNotFound(t, err)
+
+	err = repo.Delete(ctx, u.ID, dom.AnyVersion)
+	assertNotFound(t, err)
+}
+
+func TestUserRepository_DuplicateEmailIsConflict(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+
+	ann := &dom.User{Email: "ann@example.com", Name: "Ann"}
+	bob := &dom.User{Email: "bob@example.com", Name: "Bob"}
+	for _, u := range []*dom.User{ann, bob} {
+		if err := repo.Create(ctx, u); err != nil {
+			t.Fatalf("create %s: %v", u.Email, err)
+		}
+	}
+
+	err := repo.Create(ctx, &dom.User{Email: ann.Email, Name: "Ann Again"})
+	if !errors.Is(err, dom.ErrEmailTaken) {
+		t.Errorf("create duplicate: got %v, want dom.ErrEmailTaken", err)
+	}
+
+	bob.Email = ann.Email
+	err = repo.Update(ctx, bob)
+	if !errors.Is(err, dom.ErrEmailTaken) {
+		t.Errorf("update to duplicate: got %v, want dom.ErrEmailTaken", err)
+	}
+	if !domcommon.IsConflict(err) {
+		t.Errorf("domcommon.IsConflict(err) = false for %v", err)
+	}
+}
+
+func TestUserRepository_StaleVersionIsPreconditionFailed(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+
+	u := &dom.User{Email: "ann@example.com", Name: "Ann"}
+	if err := repo.Create(ctx, u); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	stale := *u
+
+	u.Name = "Ann B"
+	if err := repo.Update(ctx, u); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if u.Version != stale.Version+1 {
+		t.Errorf("version after update = %d, want %d", u.Version, stale.Version+1)
+	}
+
+	stale.Name = "Ann C"
+	err := repo.Update(ctx, &stale)
+	if !errors.Is(err, dom.ErrStaleVersion) {
+		t.Errorf("update with stale version: got %v, want dom.ErrStaleVersion", err)
+	}
+
+	err = repo.Delete(ctx, u.ID, stale.Version)
+	if !domcommon.IsPreconditionFailed(err) {
+		t.Errorf("delete with stale version: got %v, want precondition failed", err)
+	}
+
+	if err := repo.Delete(ctx, u.ID, u.Version); err != nil {
+		t.Errorf("delete with current version: %v", err)
+	}
+}
+
+func TestUserRepository_SoftDeleteRestoreAndPurge(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+
+	u := &dom.User{Email: "ann@example.com", Name: "Ann"}
+	if err := repo.Create(ctx, u); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := repo.Delete(ctx, u.ID, u.Version); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	if n, err := repo.Count(ctx, dom.ListFilter{}); err != nil || n != 0 {
+		t.Errorf("count without deleted = %d, %v; want 0", n, err)
+	}
+	listed, err := repo.List(ctx, dom.ListFilter{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("list with deleted: %v", err)
+	}
+	if len(listed) != 1 || listed[0].DeletedAt == nil {
+		t.Fatalf("list with deleted = %+v, want one soft-deleted user", listed)
+	}
+
+	restored, err := repo.Restore(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if restored.DeletedAt != nil || restored.Version != u.Version+2 {
+		t.Errorf("restored = %+v, want live user at version %d", restored, u.Version+2)
+	}
+
+	if err := repo.Delete(ctx, u.ID, dom.AnyVersion); err != nil {
+		t.Fatalf("delete again: %v", err)
+	}
+	if purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour), 10); err != nil || len(purged) != 0 {
+		t.Errorf("purge before retention = %v, %v; want nothing", purged, err)
+	}
+	purged, err := repo.Purge(ctx, time.Now().Add(time.Second), 10)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if len(purged) != 1 || purged[0].ID != u.ID || purged[0].Email != u.Email {
+		t.Errorf("purged = %+v, want [%+v]", purged, u)
+	}
+
+	_, err = repo.Restore(ctx, u.ID)
+	assertNotFound(t, err)
+}
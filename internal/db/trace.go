@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"kabsa/internal/logging"
+)
+
+const tracerName = "kabsa/internal/db"
+
+// queryObserver turns every statement into a span and logs slow ones.
+type queryObserver struct {
+	tracer trace.Tracer
+	attrs  []attribute.KeyValue // db.system and which pool served the query
+	slow   time.Duration        // 0 disables the slow-query log
+	logger logging.Logger
+}
+
+func newQueryObserver(sqlDialect, pool string, slow time.Duration, logger logging.Logger) *queryObserver {
+	system := sqlDialect
+	if sqlDialect == dialect.Postgres {
+		system = "postgresql"
+	}
+	return &queryObserver{
+		tracer: otel.Tracer(tracerName),
+		attrs: []attribute.KeyValue{
+			attribute.String("db.system.name", system),
+			attribute.String("db.client.pool", pool),
+		},
+		slow:   slow,
+		logger: logger,
+	}
+}
+
+// observe runs do inside a client span for query. Arguments are never
+// recorded and string literals in the text are masked, so values don't leak
+// into traces or logs.
+func (o *queryObserver) observe(ctx context.Context, query string, do func(ctx context.Context) (rows int64, err error)) error {
+	text := redactQuery(query)
+	op := queryOperation(text)
+
+	ctx, span := o.tracer.Start(ctx, op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(o.attrs...),
+		trace.WithAttributes(
+			attribute.String("db.query.text", text),
+			attribute.String("db.operation.name", op),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	rows, err := do(ctx)
+	elapsed := time.Since(start)
+
+	if rows >= 0 {
+		span.SetAttributes(attribute.Int64("db.response.rows_affected", rows))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	if o.slow > 0 && elapsed >= o.slow {
+		kv := []any{
+			"query", text,
+			"duration_ms", elapsed.Milliseconds(),
+			"rows_affected", rows,
+			"trace_id", span.SpanContext().TraceID().String(),
+		}
+		if err != nil {
+			kv = append(kv, "error", err)
+		}
+		o.logger.Warn("slow query", kv...)
+	}
+	return err
+}
+
+var stringLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+
+func redactQuery(query string) string {
+	return stringLiteral.ReplaceAllString(strings.TrimSpace(query), "'?'")
+}
+
+// queryOperation is the statement's leading keyword, e.g. SELECT.
+func queryOperation(query string) string {
+	op, _, _ := strings.Cut(query, " ")
+	return strings.ToUpper(op)
+}
+
+// rowsAffected reads the count from the *sql.Result Ent passes to Exec,
+// or -1 when there is none.
+func rowsAffected(v any) int64 {
+	res, ok := v.(*sql.Result)
+	if !ok || res == nil || *res == nil {
+		return -1
+	}
+	n, err := (*res).RowsAffected()
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// tracedDriver wraps an Ent SQL driver with queryObserver. It also keeps the
+// optional methods Ent looks for (BeginTx, ExecContext, QueryContext).
+type tracedDriver struct {
+	*entsql.Driver
+	obs *queryObserver
+}
+
+var _ dialect.Driver = (*tracedDriver)(nil)
+
+func (d *tracedDriver) Exec(ctx context.Context, query string, args, v any) error {
+	return d.obs.observe(ctx, query, func(ctx context.Context) (int64, error) {
+		err := d.Driver.Exec(ctx, query, args, v)
+		return rowsAffected(v), err
+	})
+}
+
+func (d *tracedDriver) Query(ctx context.Context, query string, args, v any) error {
+	return d.obs.observe(ctx, query, func(ctx context.Context) (int64, error) {
+		return -1, d.Driver.Query(ctx, query, args, v)
+	})
+}
+
+func (d *tracedDriver) ExecContext(ctx context.Context, query string, args ...any) (res sql.Result, err error) {
+	err = d.obs.observe(ctx, query, func(ctx context.Context) (int64, error) {
+		res, err = d.Driver.ExecContext(ctx, query, args...)
+		return rowsAffected(&res), err
+	})
+	return res, err
+}
+
+func (d *tracedDriver) QueryContext(ctx context.Context, query string, args ...any) (rows *sql.Rows, err error) {
+	err = d.obs.observe(ctx, query, func(ctx context.Context) (int64, error) {
+		rows, err = d.Driver.QueryContext(ctx, query, args...)
+		return -1, err
+	})
+	return rows, err
+}
+
+func (d *tracedDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.BeginTx(ctx, nil)
+}
+
+func (d *tracedDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	tx, err := d.Driver.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{Tx: tx, obs: d.obs}, nil
+}
+
+// tracedTx traces the statements of one transaction.
+type tracedTx struct {
+	dialect.Tx
+	obs *queryObserver
+}
+
+func (t *tracedTx) Exec(ctx context.Context, query string, args, v any) error {
+	return t.obs.observe(ctx, query, func(ctx context.Context) (int64, error) {
+		err := t.Tx.Exec(ctx, query, args, v)
+		return rowsAffected(v), err
+	})
+}
+
+func (t *tracedTx) Query(ctx context.Context, query string, args, v any) error {
+	return t.obs.observe(ctx, query, func(ctx context.Context) (int64, error) {
+		return -1, t.Tx.Query(ctx, query, args, v)
+	})
+}
+
+// ExecContext is what WithTx uses for savepoints.
+func (t *tracedTx) ExecContext(ctx context.Context, query string, args ...any) (res sql.Result, err error) {
+	ex, ok := t.Tx.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.ExecContext is not supported")
+	}
+	err = t.obs.observe(ctx, query, func(ctx context.Context) (int64, error) {
+		res, err = ex.ExecContext(ctx, query, args...)
+		return rowsAffected(&res), err
+	})
+	return res, err
+}
+
+func (t *tracedTx) QueryContext(ctx context.Context, query string, args ...any) (rows *sql.Rows, err error) {
+	q, ok := t.Tx.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.QueryContext is not supported")
+	}
+	err = t.obs.observe(ctx, query, func(ctx context.Context) (int64, error) {
+		rows, err = q.QueryContext(ctx, query, args...)
+		return -1, err
+	})
+	return rows, err
+}
@@ -0,0 +1,91 @@
+package db_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"kabsa/internal/db"
+	"kabsa/internal/db/dbtest"
+	"kabsa/internal/logging"
+)
+
+func TestClient_QueriesAreTracedWithoutValues(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	c := dbtest.NewClient(t)
+	ctx := context.Background()
+	rec.Reset() // drop schema creation
+
+	if err := createUser(ctx, c, "secret@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Ent().ExecContext(ctx, "UPDATE users SET name = 'also secret' WHERE email <> ''"); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := rec.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	for _, s := range spans {
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, kv := range s.Attributes() {
+			attrs[kv.Key] = kv.Value
+		}
+
+		text := attrs["db.query.text"].AsString()
+		if text == "" || strings.Contains(text, "secret") {
+			t.Errorf("span %q: db.query.text = %q, want statement without values", s.Name(), text)
+		}
+		if got := attrs["db.client.pool"].AsString(); got != "primary" {
+			t.Errorf("span %q: db.client.pool = %q, want primary", s.Name(), got)
+		}
+	}
+	if got := spans[1].Name(); got != "UPDATE" {
+		t.Errorf("span name = %q, want UPDATE", got)
+	}
+	for _, kv := range spans[1].Attributes() {
+		if kv.Key == "db.response.rows_affected" && kv.Value.AsInt64() != 1 {
+			t.Errorf("rows_affected = %d, want 1", kv.Value.AsInt64())
+		}
+	}
+}
+
+// levelLogger records messages by level.
+type levelLogger struct {
+	logged map[string][]string
+}
+
+func (l *levelLogger) log(level, msg string)      { l.logged[level] = append(l.logged[level], msg) }
+func (l *levelLogger) Info(msg string, _ ...any)  { l.log("info", msg) }
+func (l *levelLogger) Warn(msg string, _ ...any)  { l.log("warn", msg) }
+func (l *levelLogger) Error(msg string, _ ...any) { l.log("error", msg) }
+func (l *levelLogger) Debug(msg string, _ ...any) { l.log("debug", msg) }
+func (l *levelLogger) With(...any) logging.Logger { return l }
+
+func TestClient_LogsSlowQueriesAsWarnings(t *testing.T) {
+	logger := &levelLogger{logged: map[string][]string{}}
+	c := db.NewClientFromDB(dbtest.OpenSQLite(t, "primary"), dialect.SQLite, logger, db.WithSlowQueryThreshold(1))
+	t.Cleanup(func() { _ = c.Close() })
+
+	if _, err := c.Ent().ExecContext(context.Background(), "SELECT 1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := logger.logged["warn"]; len(got) != 1 || got[0] != "slow query" {
+		t.Errorf("warnings = %v, want one slow query", got)
+	}
+	if got := logger.logged["info"]; len(got) != 0 {
+		t.Errorf("info = %v, want none", got)
+	}
+}
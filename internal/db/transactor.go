@@ -2,6 +2,8 @@
 
 import "context"
 
+// Transactor lets the app layer group repository calls atomically without
+// depending on Ent; see Client.WithTx.
 type Transactor interface {
-	WithTx(ctx context.Context, fn TxFunc) error
+	WithTx(ctx context.Context, fn TxFunc, opts ...TxOption) error
 }
@@ -2,18 +2,80 @@
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"kabsa/ent"
 )
 
-type TxFunc func(ctx context.Context, tx *ent.Tx) error
+// TxFunc is the body of a transaction. The ctx it receives carries the
+// transaction: repositories resolve it with Client.EntFor, so callers
+// compose them without ever touching Ent.
+type TxFunc func(ctx context.Context) error
 
-// WithTx runs the given function in a transaction.
-func (c *Client) WithTx(ctx context.Context, fn TxFunc) error {
-	tx, err := c.ent.Tx(ctx)
+type txKey struct{}
+
+// txState is what WithTx stores in the context.
+type txState struct {
+	tx    *ent.Tx
+	depth int // savepoint nesting level, 0 for the outermost transaction
+}
+
+func txFromContext(ctx context.Context) *ent.Tx {
+	if st, ok := ctx.Value(txKey{}).(*txState); ok {
+		return st.tx
+	}
+	return nil
+}
+
+// InTx reports whether ctx carries a transaction started by WithTx.
+func InTx(ctx context.Context) bool {
+	return txFromContext(ctx) != nil
+}
+
+// WithTx runs fn in a transaction, committing if it returns nil and rolling
+// back if it returns an error or panics. If the transaction fails with a
+// serialization failure or deadlock, it is re-run from scratch with jittered
+// backoff, up to the retry budget (see WithRetries).
+//
+// Called under a ctx that already carries a transaction, WithTx nests: fn
+// runs inside a savepoint of the outer transaction, and its failure only
+// undoes its own work. The outer transaction decides whether anything is
+// committed, and is the one that retries.
+func (c *Client) WithTx(ctx context.Context, fn TxFunc, opts ...TxOption) error {
+	if st, ok := ctx.Value(txKey{}).(*txState); ok {
+		return withSavepoint(ctx, st, fn)
+	}
+
+	cfg := txConfig{maxRetries: defaultTxRetries}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := c.runTx(ctx, &cfg.opts, fn)
+		state, retryable := retryableSQLState(err)
+		if !retryable {
+			return err
+		}
+		if attempt >= cfg.maxRetries {
+			c.metrics.txGaveUp(ctx, state)
+			return err
+		}
+
+		c.metrics.txRetried(ctx, state)
+		c.logger.Debug("retrying transaction", "sqlstate", state, "attempt", attempt+1)
+		if sleepErr := sleepCtx(ctx, txBackoff(attempt)); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) runTx(ctx context.Context, opts *sql.TxOptions, fn TxFunc) error {
+	tx, err := c.ent.BeginTx(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("start tx: %w", err)
 	}
+	ctx = context.WithValue(ctx, txKey{}, &txState{tx: tx})
 
 	// If fn returns error, rollback.
 	defer func() {
@@ -23,7 +85,7 @@ func (c *Client) WithTx(ctx context.Context, fn TxFunc) error {
 		}
 	}()
 
-	if err := fn(ctx, tx); err != nil {
+	if err := fn(ctx); err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return fmt.Errorf("tx rollback: %v (original error: %w)", rbErr, err)
 		}
@@ -35,3 +97,36 @@ func (c *Client) WithTx(ctx context.Context, fn TxFunc) error {
 	}
 	return nil
 }
+
+func withSavepoint(ctx context.Context, outer *txState, fn TxFunc) error {
+	st := &txState{tx: outer.tx, depth: outer.depth + 1}
+	name := fmt.Sprintf("sp_%d", st.depth)
+	exec := func(stmt string) error {
+		_, err := st.tx.Client().ExecContext(ctx, stmt+" "+name)
+		return err
+	}
+
+	if err := exec("SAVEPOINT"); err != nil {
+		return fmt.Errorf("savepoint: %w", err)
+	}
+	ctx = context.WithValue(ctx, txKey{}, st)
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = exec("ROLLBACK TO SAVEPOINT")
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		if rbErr := exec("ROLLBACK TO SAVEPOINT"); rbErr != nil {
+			return fmt.Errorf("rollback to savepoint: %v (original error: %w)", rbErr, err)
+		}
+		return err
+	}
+
+	if err := exec("RELEASE SAVEPOINT"); err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// TxOption configures a top-level WithTx call. Nested calls run in a
+// savepoint of the outer transaction and ignore their options.
+type TxOption func(*txConfig)
+
+type txConfig struct {
+	opts       sql.TxOptions
+	maxRetries int
+}
+
+const (
+	defaultTxRetries = 3
+	txBackoffBase    = 10 * time.Millisecond
+	txBackoffMax     = time.Second
+)
+
+// WithIsolation runs the transaction at level, e.g. sql.LevelSerializable.
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(c *txConfig) { c.opts.Isolation = level }
+}
+
+// ReadOnly starts a read-only transaction.
+func ReadOnly() TxOption {
+	return func(c *txConfig) { c.opts.ReadOnly = true }
+}
+
+// WithRetries sets how many times the whole transaction is re-run after a
+// serialization failure or deadlock (default 3; 0 disables retrying).
+// fn must be safe to run again: it sees a fresh transaction each time, but
+// side effects outside the database are not undone.
+func WithRetries(n int) TxOption {
+	return func(c *txConfig) { c.maxRetries = max(n, 0) }
+}
+
+// Postgres SQLSTATEs after which re-running the transaction may succeed.
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// retryableSQLState returns the SQLSTATE of err if the transaction that
+// produced it can simply be re-run.
+func retryableSQLState(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return "", false
+	}
+	switch pgErr.Code {
+	case sqlStateSerializationFailure, sqlStateDeadlockDetected:
+		return pgErr.Code, true
+	}
+	return "", false
+}
+
+// txBackoff is "full jitter" exponential backoff: a random delay up to
+// base*2^attempt, capped, so retrying transactions don't collide again.
+func txBackoff(attempt int) time.Duration {
+	d := txBackoffBase << min(attempt, 16)
+	return rand.N(min(d, txBackoffMax)) + time.Millisecond
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
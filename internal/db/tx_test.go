@@ -0,0 +1,127 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	entuser "kabsa/ent/user"
+	"kabsa/internal/db"
+	"kabsa/internal/db/dbtest"
+)
+
+func createUser(ctx context.Context, c *db.Client, email string) error {
+	return c.EntFor(ctx).User.Create().SetEmail(email).SetName("Test").Exec(ctx)
+}
+
+func TestWithTx_NestedFailureRollsBackToSavepoint(t *testing.T) {
+	c := dbtest.NewClient(t)
+	ctx := context.Background()
+	errInner := errors.New("inner failed")
+
+	err := c.WithTx(ctx, func(ctx context.Context) error {
+		if !db.InTx(ctx) {
+			t.Error("InTx = false inside WithTx")
+		}
+		if err := createUser(ctx, c, "outer@example.com"); err != nil {
+			return err
+		}
+
+		err := c.WithTx(ctx, func(ctx context.Context) error {
+			if err := createUser(ctx, c, "inner@example.com"); err != nil {
+				return err
+			}
+			return errInner
+		})
+		if !errors.Is(err, errInner) {
+			t.Errorf("nested WithTx = %v, want errInner", err)
+		}
+
+		return c.WithTx(ctx, func(ctx context.Context) error {
+			return createUser(ctx, c, "second@example.com")
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+
+	emails, err := c.Ent().User.Query().Order(entuser.ByID()).Select(entuser.FieldEmail).Strings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(emails); got != "[outer@example.com second@example.com]" {
+		t.Errorf("committed users = %s, want outer and second only", got)
+	}
+}
+
+func TestWithTx_OuterFailureDiscardsReleasedSavepoints(t *testing.T) {
+	c := dbtest.NewClient(t)
+	ctx := context.Background()
+	errOuter := errors.New("outer failed")
+
+	err := c.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.WithTx(ctx, func(ctx context.Context) error {
+			return createUser(ctx, c, "inner@example.com")
+		}); err != nil {
+			return err
+		}
+		return errOuter
+	})
+	if !errors.Is(err, errOuter) {
+		t.Fatalf("WithTx = %v, want errOuter", err)
+	}
+
+	n, err := c.Ent().User.Query().Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("users after rollback = %d, want 0", n)
+	}
+}
+
+func TestWithTx_RetriesSerializationFailures(t *testing.T) {
+	c := dbtest.NewClient(t)
+	ctx := context.Background()
+	serialization := &pgconn.PgError{Code: db.SQLStateSerializationFailure}
+
+	t.Run("succeeds within budget", func(t *testing.T) {
+		attempts := 0
+		err := c.WithTx(ctx, func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				// Fails from a nested call too: the whole transaction re-runs.
+				return c.WithTx(ctx, func(context.Context) error { return serialization })
+			}
+			return nil
+		})
+		if err != nil || attempts != 3 {
+			t.Errorf("WithTx = %v after %d attempts, want nil after 3", err, attempts)
+		}
+	})
+
+	t.Run("gives up when budget is spent", func(t *testing.T) {
+		attempts := 0
+		err := c.WithTx(ctx, func(context.Context) error {
+			attempts++
+			return serialization
+		}, db.WithRetries(1))
+		if !errors.Is(err, serialization) || attempts != 2 {
+			t.Errorf("WithTx = %v after %d attempts, want serialization failure after 2", err, attempts)
+		}
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		attempts := 0
+		err := c.WithTx(ctx, func(context.Context) error {
+			attempts++
+			return &pgconn.PgError{Code: "23505"}
+		})
+		if err == nil || attempts != 1 {
+			t.Errorf("WithTx = %v after %d attempts, want error after 1", err, attempts)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+// Package audit describes the record kept of every mutation: who made it,
+// what changed, and which request and trace it came from.
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+type Action string
+
+const (
+	ActionCreated  Action = "created"
+	ActionUpdated  Action = "updated"
+	ActionDeleted  Action = "deleted"
+	ActionRestored Action = "restored"
+	ActionPurged   Action = "purged"
+)
+
+// Change is the old and new value of one field. From is nil for created
+// fields and To is nil for removed ones.
+type Change struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type Entry struct {
+	ID         int64
+	Actor      string
+	Action     Action
+	EntityType string
+	EntityID   int64
+	Changes    map[string]Change
+	RequestID  string
+	TraceID    string
+	CreatedAt  time.Time
+}
+
+type ListFilter struct {
+	Limit int
+	// BeforeID returns only entries older than this one; 0 starts at the newest.
+	BeforeID int64
+}
+
+type Repository interface {
+	// Append stores e and sets its ID and CreatedAt. Called inside the
+	// transaction of the change it records.
+	Append(ctx context.Context, e *Entry) error
+	// List returns an entity's entries newest first.
+	List(ctx context.Context, entityType string, entityID int64, filter ListFilter) ([]Entry, error)
+}
+
+// Diff returns the fields whose values differ between before and after.
+// A field missing from one side is compared as nil. Values must be
+// comparable with ==, so pass times and the like already formatted.
+func Diff(before, after map[string]any) map[string]Change {
+	changes := make(map[string]Change)
+	for k, to := range after {
+		if from := before[k]; from != to {
+			changes[k] = Change{From: from, To: to}
+		}
+	}
+	for k, from := range before {
+		if _, ok := after[k]; !ok && from != nil {
+			changes[k] = Change{From: from}
+		}
+	}
+	return changes
+}
+
+// UnknownActor is recorded when the context carries no actor.
+const UnknownActor = "unknown"
+
+// UnverifiedActorPrefix marks actors claimed by a request that did not come
+// through a trusted proxy, so nothing vouches for them.
+const UnverifiedActorPrefix = "unverified:"
+
+type actorKey struct{}
+type requestIDKey struct{}
+
+// WithActor attributes changes made with ctx to actor, e.g. a user reference
+// from the request or "system:purge" for background jobs.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor set by WithActor, or UnknownActor.
+func ActorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return UnknownActor
+}
+
+// WithRequestID records the ID of the request ctx belongs to.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFrom returns the ID set by WithRequestID, or "".
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
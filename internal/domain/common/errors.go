@@ -3,8 +3,13 @@
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
+// The error taxonomy shared by every layer. Repositories and services return
+// these (wrapped or not); the HTTP layer maps them to status codes in one place.
+
+// NotFoundError means the requested entity does not exist.
 type NotFoundError struct {
 	Entity string
 }
@@ -21,3 +26,196 @@ func IsNotFound(err error) bool {
 	var nf NotFoundError
 	return errors.As(err, &nf)
 }
+
+// ConflictError means the change clashes with existing state, e.g. a unique
+// field that is already taken. Field is optional.
+type ConflictError struct {
+	Entity  string
+	Field   string
+	Message string
+}
+
+func (e ConflictError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Field != "" {
+		return fmt.Sprintf("%s with this %s already exists", e.Entity, e.Field)
+	}
+	return fmt.Sprintf("%s conflict", e.Entity)
+}
+
+func NewConflict(entity, field, msg string) error {
+	return ConflictError{Entity: entity, Field: field, Message: msg}
+}
+
+func IsConflict(err error) bool {
+	var c ConflictError
+	return errors.As(err, &c)
+}
+
+// PreconditionFailedError means the caller's view of the entity is stale:
+// it was changed since the version the caller based its change on.
+type PreconditionFailedError struct {
+	Entity string
+}
+
+func (e PreconditionFailedError) Error() string {
+	return fmt.Sprintf("%s was modified by someone else", e.Entity)
+}
+
+func NewPreconditionFailed(entity string) error {
+	return PreconditionFailedError{Entity: entity}
+}
+
+func IsPreconditionFailed(err error) bool {
+	var p PreconditionFailedError
+	return errors.As(err, &p)
+}
+
+// PreconditionRequiredError means the caller must say which version it is
+// changing (e.g. If-Match) and didn't.
+type PreconditionRequiredError struct {
+	Message string
+}
+
+func (e PreconditionRequiredError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return "precondition required"
+}
+
+func NewPreconditionRequired(msg string) error {
+	return PreconditionRequiredError{Message: msg}
+}
+
+func IsPreconditionRequired(err error) bool {
+	var p PreconditionRequiredError
+	return errors.As(err, &p)
+}
+
+// FieldError describes why a single input field was rejected.
+type FieldError struct {
+	Field   string // name as the client sent it, e.g. "email"
+	Rule    string // failed rule, e.g. "required", "email", "max"
+	Message string // human-readable explanation
+}
+
+// ValidationError means the input was malformed or failed validation rules.
+type ValidationError struct {
+	Message string
+	Fields  []FieldError
+}
+
+func (e ValidationError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if len(e.Fields) > 0 {
+		f := e.Fields[0]
+		return fmt.Sprintf("invalid %s: %s", f.Field, f.Message)
+	}
+	return "validation failed"
+}
+
+func NewValidation(msg string, fields ...FieldError) error {
+	return ValidationError{Message: msg, Fields: fields}
+}
+
+// NewFieldValidation is shorthand for a validation error on a single field.
+func NewFieldValidation(field, rule, msg string) error {
+	return ValidationError{Fields: []FieldError{{Field: field, Rule: rule, Message: msg}}}
+}
+
+func IsValidation(err error) bool {
+	var v ValidationError
+	return errors.As(err, &v)
+}
+
+// UnauthorizedError means the caller is not authenticated.
+type UnauthorizedError struct {
+	Message string
+}
+
+func (e UnauthorizedError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return "unauthorized"
+}
+
+func NewUnauthorized(msg string) error {
+	return UnauthorizedError{Message: msg}
+}
+
+func IsUnauthorized(err error) bool {
+	var u UnauthorizedError
+	return errors.As(err, &u)
+}
+
+// ForbiddenError means the caller is authenticated but not allowed.
+type ForbiddenError struct {
+	Message string
+}
+
+func (e ForbiddenError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return "forbidden"
+}
+
+func NewForbidden(msg string) error {
+	return ForbiddenError{Message: msg}
+}
+
+func IsForbidden(err error) bool {
+	var f ForbiddenError
+	return errors.As(err, &f)
+}
+
+// RateLimitedError means the caller should back off. RetryAfter is optional.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e RateLimitedError) Error() string {
+	return "rate limit exceeded"
+}
+
+func NewRateLimited(retryAfter time.Duration) error {
+	return RateLimitedError{RetryAfter: retryAfter}
+}
+
+func IsRateLimited(err error) bool {
+	var r RateLimitedError
+	return errors.As(err, &r)
+}
+
+// UnavailableError means a dependency is down and the request may succeed later.
+type UnavailableError struct {
+	Dependency string
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e UnavailableError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s unavailable: %v", e.Dependency, e.Err)
+	}
+	return fmt.Sprintf("%s unavailable", e.Dependency)
+}
+
+func (e UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+func NewUnavailable(dependency string, err error) error {
+	return UnavailableError{Dependency: dependency, Err: err}
+}
+
+func IsUnavailable(err error) bool {
+	var u UnavailableError
+	return errors.As(err, &u)
+}
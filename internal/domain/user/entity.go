@@ -8,4 +8,8 @@ type User struct {
 	Name      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// Version increases by one on every update; see Repository.Update.
+	Version int64
+	// DeletedAt is set while the user is soft-deleted; see Repository.Delete.
+	DeletedAt *time.Time
 }
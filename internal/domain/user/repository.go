@@ -2,21 +2,103 @@
 
 import (
 	"context"
-	"errors"
+	domcommon "kabsa/internal/domain/common"
+	"time"
 )
 
-var ErrNotFound = errors.New("user not found")
+// ErrNotFound is returned by Repository methods when the user does not exist.
+// It is a domcommon.NotFoundError, so both errors.Is(err, ErrNotFound) and
+// domcommon.IsNotFound(err) hold for it.
+var ErrNotFound error = domcommon.NotFoundError{Entity: "user"}
+
+// ErrEmailTaken is returned by Create/Update when another user already has the
+// email. It is a domcommon.ConflictError on the "email" field.
+var ErrEmailTaken error = domcommon.ConflictError{
+	Entity:  "user",
+	Field:   "email",
+	Message: "email is already taken",
+}
+
+// ErrStaleVersion is returned by Update/Delete when the stored version no
+// longer matches the one the caller expected.
+var ErrStaleVersion error = domcommon.PreconditionFailedError{Entity: "user"}
+
+// AnyVersion makes Delete unconditional. Versions start at 1.
+const AnyVersion int64 = 0
+
+// SortField is a column users can be ordered by.
+type SortField string
+
+const (
+	SortByID        SortField = "id"
+	SortByEmail     SortField = "email"
+	SortByName      SortField = "name"
+	SortByCreatedAt SortField = "created_at"
+)
+
+// Valid reports whether f is one of the supported sort fields.
+func (f SortField) Valid() bool {
+	switch f {
+	case SortByID, SortByEmail, SortByName, SortByCreatedAt:
+		return true
+	}
+	return false
+}
+
+// Cursor is a keyset position: the sort key of the last row already returned.
+type Cursor struct {
+	ID int64
+	// Value is the SortBy column of that row: string for email/name,
+	// time.Time for created_at, unused when sorting by ID.
+	Value any
+}
 
 type ListFilter struct {
 	Limit  int
 	Offset int
+	// After switches to keyset pagination: only rows strictly after this
+	// position (in SortBy/SortDesc order) are returned. Offset is ignored.
+	After *Cursor
+
+	// SortBy defaults to SortByID. Ties are always broken by ID.
+	SortBy   SortField
+	SortDesc bool
+
+	// Optional filters; zero values are ignored.
+	Email         string // exact match, case-insensitive
+	NameContains  string // substring match, case-insensitive
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	// IncludeDeleted also returns soft-deleted users.
+	IncludeDeleted bool
 }
 
 type Repository interface {
 	GetById(ctx context.Context, id int64) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	List(ctx context.Context, filter ListFilter) ([]User, error)
+	// Count returns the number of users matching filter, ignoring Limit/Offset/Sort.
+	Count(ctx context.Context, filter ListFilter) (int, error)
 	Create(ctx context.Context, u *User) error
+	// CreateBulk inserts users in one statement and fills in their generated
+	// fields. It fails as a whole, e.g. with ErrEmailTaken if any email is.
+	CreateBulk(ctx context.Context, users []*User) error
+	// TakenEmails returns which of emails belong to existing users,
+	// soft-deleted ones included since they keep their email until purged.
+	TakenEmails(ctx context.Context, emails []string) ([]string, error)
+	// Update saves u only if the stored version still equals u.Version,
+	// returning ErrStaleVersion otherwise. On success u.Version and
+	// u.UpdatedAt are refreshed.
 	Update(ctx context.Context, u *User) error
-	Delete(ctx context.Context, id int64) error
+	// Delete soft-deletes the user if its version equals expectedVersion,
+	// or unconditionally for AnyVersion. Soft-deleted users are invisible to
+	// every other method (except List with IncludeDeleted) until restored.
+	Delete(ctx context.Context, id int64, expectedVersion int64) error
+	// Restore undoes a soft delete and returns the user. Restoring a user
+	// that isn't deleted is a no-op.
+	Restore(ctx context.Context, id int64) (*User, error)
+	// Purge hard-deletes up to limit users soft-deleted before deletedBefore
+	// and returns them as they were just before removal.
+	Purge(ctx context.Context, deletedBefore time.Time, limit int) ([]User, error)
 }
@@ -13,12 +13,25 @@ type UserResponse struct {
 	ID    int64  `json:"id" example:"1"`
 	Name  string `json:"name" example:"Jane Doe"`
 	Email string `json:"email" example:"jane@example.com"`
+	// Only present in listings with include_deleted=true.
+	DeletedAt string `json:"deletedAt,omitempty" example:"2025-01-31T12:00:00Z"`
 }
 
-// UsersListResponse wraps a list.
+// PageLinks are relative links to neighbouring pages of a list.
+type PageLinks struct {
+	Self string `json:"self" example:"/api/v1/users?limit=50&offset=50"`
+	Next string `json:"next,omitempty" example:"/api/v1/users?limit=50&offset=100"`
+	Prev string `json:"prev,omitempty" example:"/api/v1/users?limit=50&offset=0"`
+}
+
+// UsersListResponse wraps a page of users. Total is omitted for cursor pages.
 type UsersListResponse struct {
-	Data    []UserResponse `json:"data"`
-	TraceID string         `json:"traceId,omitempty"`
+	Data       []UserResponse `json:"data"`
+	Total      int            `json:"total,omitempty" example:"123"`
+	Limit      int            `json:"limit" example:"50"`
+	Offset     int            `json:"offset" example:"50"`
+	NextCursor string         `json:"nextCursor,omitempty" example:"eyJzIjoiaWQiLCJpZCI6NTB9.c2lnbmF0dXJl"`
+	Links      PageLinks      `json:"links"`
 }
 
 // UserItemResponse wraps one item.
@@ -27,10 +40,76 @@ type UserItemResponse struct {
 	TraceID string       `json:"traceId,omitempty"`
 }
 
-// ErrorEnvelope matches your error writer.
-type ErrorEnvelope struct {
-	Code    string      `json:"code" example:"not_found"`
-	Message string      `json:"message" example:"The requested resource was not found."`
-	Details interface{} `json:"details,omitempty"`
-	TraceID string      `json:"traceId,omitempty"`
+// ProblemField is one per-field entry of Problem.details.
+type ProblemField struct {
+	Field   string `json:"field" example:"email"`
+	Rule    string `json:"rule,omitempty" example:"email"`
+	Message string `json:"message" example:"must be a valid email address"`
+}
+
+// Problem is the RFC 7807 application/problem+json body of every error response.
+type Problem struct {
+	Type     string         `json:"type" example:"about:blank"`
+	Title    string         `json:"title" example:"Not Found"`
+	Status   int            `json:"status" example:"404"`
+	Detail   string         `json:"detail,omitempty" example:"user not found"`
+	Instance string         `json:"instance,omitempty" example:"/api/v1/users/42"`
+	Code     string         `json:"code" example:"not_found"`
+	Details  []ProblemField `json:"details,omitempty"`
+	TraceID  string         `json:"traceId,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+}
+
+// AuditChange is the old and new value of one field in an audit entry.
+type AuditChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// AuditEntryResponse is one audited change.
+type AuditEntryResponse struct {
+	ID        int64                  `json:"id" example:"7"`
+	Actor     string                 `json:"actor" example:"admin@example.com"`
+	Action    string                 `json:"action" example:"updated" enums:"created,updated,deleted,restored,purged"`
+	Changes   map[string]AuditChange `json:"changes"`
+	RequestID string                 `json:"requestId,omitempty" example:"host/abc123-000042"`
+	TraceID   string                 `json:"traceId,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+	CreatedAt string                 `json:"createdAt" example:"2025-01-31T12:00:00Z"`
+}
+
+// UserHistoryResponse is a page of a user's history, newest first.
+type UserHistoryResponse struct {
+	Data  []AuditEntryResponse `json:"data"`
+	Limit int                  `json:"limit" example:"50"`
+	Links PageLinks            `json:"links"`
+}
+
+// BatchResult is the outcome of operations[index]: the status the operation
+// would have had on its own, with the user or the problem.
+type BatchResult struct {
+	Index  int           `json:"index" example:"0"`
+	Status int           `json:"status" example:"201"`
+	Data   *UserResponse `json:"data,omitempty"`
+	Error  *Problem      `json:"error,omitempty"`
+}
+
+// UserBatchResponse has one result per operation, in request order.
+type UserBatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// ImportError is why the row starting at line was not imported.
+type ImportError struct {
+	Line    int            `json:"line" example:"3"`
+	Code    string         `json:"code" example:"conflict"`
+	Detail  string         `json:"detail,omitempty" example:"email is already taken"`
+	Details []ProblemField `json:"details,omitempty"`
+}
+
+// UserImportResponse summarizes an import. error is set when it stopped early.
+type UserImportResponse struct {
+	Created         int           `json:"created" example:"998"`
+	Failed          int           `json:"failed" example:"2"`
+	Errors          []ImportError `json:"errors,omitempty"`
+	ErrorsTruncated bool          `json:"errorsTruncated,omitempty"`
+	Error           *Problem      `json:"error,omitempty"`
 }
@@ -26,7 +26,7 @@ func NewHandler(dbClient *db.Client, redisClient *cache.RedisClient) *Handler {
 //	@Tags			health
 //	@Produce		json
 //	@Success		200	{object}	apidocs.HealthResponse
-//	@Failure		500	{object}	apidocs.ErrorEnvelope
+//	@Failure		500	{object}	apidocs.Problem
 //	@Router			/health [get]
 func (h *Handler) Check(w http.ResponseWriter, r *http.Request) {
 	// For now, just return OK. Later you can add DB/Redis ping logic here.
@@ -0,0 +1,117 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	appuser "kabsa/internal/app/user"
+	domcommon "kabsa/internal/domain/common"
+	"kabsa/internal/http/request"
+	"kabsa/internal/http/responses"
+	"net/http"
+)
+
+// DefaultMaxBatchSize is used when Options.MaxBatchSize is not set.
+const DefaultMaxBatchSize = 100
+
+// Batch godoc
+//
+//	@Summary		Batch create, update and delete users
+//	@Description	Runs each operation on its own, in order, and reports a status per operation.
+//	@Description	A failed operation does not undo or stop the others. update has PATCH semantics
+//	@Description	and version plays the role of If-Match.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		user.BatchRequest	true	"Operations"
+//	@Success		200		{object}	apidocs.UserBatchResponse
+//	@Failure		400		{object}	apidocs.Problem
+//	@Failure		415		{object}	apidocs.Problem
+//	@Failure		500		{object}	apidocs.Problem
+//	@Router			/users:batch [post]
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !request.IsJSON(r) {
+		responses.WriteUnsupportedMediaType(w, r, request.JSONContentType)
+		return
+	}
+
+	var input BatchRequest
+	if err := request.Bind(w, r, &input); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if max := h.maxBatchSize(); len(input.Operations) > max {
+		h.writeError(w, r, domcommon.NewFieldValidation("operations", "max",
+			fmt.Sprintf("must contain at most %d operations", max)))
+		return
+	}
+
+	results := make([]BatchResult, len(input.Operations))
+	for i, op := range input.Operations {
+		status, dto, err := h.runBatchOperation(ctx, op)
+		results[i] = BatchResult{Index: i, Status: status, Data: dto}
+		if err != nil {
+			p := responses.ProblemFor(err)
+			if p.Status >= http.StatusInternalServerError {
+				h.logger.Error("batch operation failed", "error", err, "index", i, "op", op.Op, "id", op.ID)
+			}
+			p.Title = http.StatusText(p.Status)
+			results[i].Status = p.Status
+			results[i].Error = &p
+		}
+	}
+
+	responses.WriteJSON(w, http.StatusOK, BatchResponse{Results: results})
+}
+
+func (h *Handler) runBatchOperation(ctx context.Context, op BatchOperation) (int, *appuser.UserDto, error) {
+	if op.Op != "create" && op.ID <= 0 {
+		return 0, nil, domcommon.NewFieldValidation("id", "required", "must be a positive integer")
+	}
+	if op.Op != "create" && op.Version == nil && h.opts.RequireIfMatch {
+		return 0, nil, domcommon.NewPreconditionRequired("version is required: send the version from a previous read")
+	}
+
+	switch op.Op {
+	case "create":
+		in := appuser.CreateUserInput{Email: deref(op.Email), Name: deref(op.Name)}
+		if err := in.Validate(); err != nil {
+			return 0, nil, err
+		}
+		dto, err := h.service.Create(ctx, in)
+		return http.StatusCreated, dto, err
+
+	case "update":
+		in := appuser.UpdateUserInput{
+			ID:              op.ID,
+			Email:           op.Email,
+			Name:            op.Name,
+			ExpectedVersion: op.Version,
+		}
+		if err := in.Validate(); err != nil {
+			return 0, nil, err
+		}
+		dto, err := h.service.Update(ctx, in)
+		return http.StatusOK, dto, err
+
+	case "delete":
+		err := h.service.Delete(ctx, appuser.DeleteUserInput{ID: op.ID, ExpectedVersion: op.Version})
+		return http.StatusNoContent, nil, err
+	}
+	return 0, nil, domcommon.NewFieldValidation("op", "oneof", "must be one of create, update, delete")
+}
+
+func (h *Handler) maxBatchSize() int {
+	if h.opts.MaxBatchSize > 0 {
+		return h.opts.MaxBatchSize
+	}
+	return DefaultMaxBatchSize
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
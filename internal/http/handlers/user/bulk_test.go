@@ -0,0 +1,171 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	appuser "kabsa/internal/app/user"
+	"kabsa/internal/db/dbtest"
+	"kabsa/internal/db/repository"
+	"kabsa/internal/logging"
+	"kabsa/internal/pagination"
+)
+
+// newDBService builds the user service over a fresh sqlite database.
+func newDBService(t *testing.T, events appuser.Events) appuser.Service {
+	t.Helper()
+
+	client := dbtest.NewClient(t)
+
+	codec, err := pagination.NewCursorCodec("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return appuser.NewService(
+		repository.NewUserRepository(client, logging.NewNop()),
+		missCache{},
+		client,
+		events,
+		repository.NewAuditRepository(client, logging.NewNop()),
+		codec,
+		logging.NewNop())
+}
+
+// newDBRouter serves the bulk endpoints from a service over a fresh sqlite
+// database, since their behaviour hinges on what is already stored.
+func newDBRouter(t *testing.T) (http.Handler, appuser.Service) {
+	t.Helper()
+
+	svc := newDBService(t, appuser.NoopEvents{})
+	h := NewHandler(svc, Options{MaxBatchSize: 3}, logging.NewNop())
+
+	r := chi.NewRouter()
+	r.Post("/users:batch", h.Batch)
+	r.Post("/users:import", h.Import)
+	r.Get("/users/export", h.Export)
+	return r, svc
+}
+
+func post(t *testing.T, router http.Handler, path, contentType, body string, dst any) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if dst != nil {
+		if err := json.NewDecoder(rec.Body).Decode(dst); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+	return rec.Code
+}
+
+func TestHandler_BatchReportsPerOperationStatus(t *testing.T) {
+	router, svc := newDBRouter(t)
+	existing, err := svc.Create(context.Background(), appuser.CreateUserInput{Email: "old@example.com", Name: "Old"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := fmt.Sprintf(`{"operations": [
+		{"op": "create", "email": "new@example.com", "name": "New"},
+		{"op": "update", "id": %d, "name": "Renamed"},
+		{"op": "create", "email": "old@example.com", "name": "Dup"}
+	]}`, existing.Id)
+
+	var resp BatchResponse
+	if code := post(t, router, "/users:batch", "application/json", body, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+
+	want := []int{http.StatusCreated, http.StatusOK, http.StatusConflict}
+	for i, res := range resp.Results {
+		if res.Status != want[i] {
+			t.Errorf("result %d status = %d, want %d (%+v)", i, res.Status, want[i], res.Error)
+		}
+	}
+	if resp.Results[1].Data == nil || resp.Results[1].Data.Name != "Renamed" {
+		t.Errorf("update result = %+v", resp.Results[1].Data)
+	}
+
+	tooMany := `{"operations": [{"op":"delete","id":1},{"op":"delete","id":2},{"op":"delete","id":3},{"op":"delete","id":4}]}`
+	if code := post(t, router, "/users:batch", "application/json", tooMany, nil); code != http.StatusBadRequest {
+		t.Errorf("oversized batch status = %d, want 400", code)
+	}
+}
+
+func TestHandler_ImportReportsErrorsByLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{
+			name:        "csv",
+			contentType: "text/csv",
+			body: "email,name\n" +
+				"a@example.com,Alice\n" +
+				"taken@example.com,Taken\n" +
+				"not-an-email,Bad\n" +
+				"a@example.com,Again\n" +
+				"b@example.com,Bob\n",
+		},
+		{
+			name:        "ndjson",
+			contentType: "application/x-ndjson",
+			body: `{"email":"a@example.com","name":"Alice"}` + "\n" +
+				`{"email":"taken@example.com","name":"Taken"}` + "\n" +
+				`{"email":"not-an-email","name":"Bad"}` + "\n" +
+				`{"email":"a@example.com","name":"Again"}` + "\n" +
+				`{"email":"b@example.com","name":"Bob"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, svc := newDBRouter(t)
+			if _, err := svc.Create(context.Background(), appuser.CreateUserInput{Email: "taken@example.com", Name: "Taken"}); err != nil {
+				t.Fatal(err)
+			}
+
+			var resp ImportResponse
+			if code := post(t, router, "/users:import", tt.contentType, tt.body, &resp); code != http.StatusOK {
+				t.Fatalf("status = %d, error %+v", code, resp.Error)
+			}
+			if resp.Created != 2 || resp.Failed != 3 {
+				t.Errorf("created %d failed %d, want 2 and 3", resp.Created, resp.Failed)
+			}
+
+			// The CSV header is line 1, so its rows start one line later.
+			offset := 0
+			if tt.contentType == "text/csv" {
+				offset = 1
+			}
+			got := map[int]string{}
+			for _, e := range resp.Errors {
+				got[e.Line-offset] = e.Code
+			}
+			want := map[int]string{2: "conflict", 3: "validation_failed", 4: "conflict"}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("errors by row = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestHandler_ImportRejectsBadHeader(t *testing.T) {
+	router, _ := newDBRouter(t)
+
+	var resp ImportResponse
+	code := post(t, router, "/users:import", "text/csv", "email,nickname\na@example.com,A\n", &resp)
+	if code != http.StatusBadRequest || resp.Error == nil {
+		t.Errorf("status = %d, error %+v; want 400 with an error", code, resp.Error)
+	}
+}
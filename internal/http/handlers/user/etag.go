@@ -0,0 +1,67 @@
+package user
+
+import (
+	domcommon "kabsa/internal/domain/common"
+	"kabsa/internal/http/request"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// etagFor renders a user version as a strong entity tag.
+func etagFor(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// expectedVersion turns If-Match into the version a write on user id is
+// conditional on. nil means unconditional: no header (unless required) or
+// "*". With several tags the current version is looked up and the write
+// proceeds if any tag matches it (RFC 9110 §13.1.1); the write itself stays
+// conditional on that version, so a concurrent change still fails it.
+func (h *Handler) expectedVersion(r *http.Request, id int64) (*int64, error) {
+	tags, ok := request.IfMatch(r)
+	if !ok {
+		if h.opts.RequireIfMatch {
+			return nil, domcommon.NewPreconditionRequired("If-Match is required: send the ETag from a previous GET")
+		}
+		return nil, nil
+	}
+	if tags.Any {
+		return nil, nil
+	}
+
+	// Weak or foreign tags can never match a strong comparison.
+	var candidates []int64
+	for _, tag := range tags.Tags {
+		if v, ok := versionOf(tag); ok {
+			candidates = append(candidates, v)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return nil, domcommon.NewPreconditionFailed("user")
+	case 1:
+		return &candidates[0], nil
+	}
+
+	current, err := h.service.GetById(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if !tags.MatchStrong(etagFor(current.Version)) {
+		return nil, domcommon.NewPreconditionFailed("user")
+	}
+	return &current.Version, nil
+}
+
+// versionOf parses a tag rendered by etagFor.
+func versionOf(tag string) (int64, bool) {
+	if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	return v, true
+}
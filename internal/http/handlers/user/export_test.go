@@ -0,0 +1,92 @@
+package user
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appuser "kabsa/internal/app/user"
+)
+
+func TestHandler_ExportCSVHonorsFilters(t *testing.T) {
+	router, svc := newDBRouter(t)
+	for _, name := range []string{"Alice", "Bob", "Alicia"} {
+		email := strings.ToLower(name) + "@example.com"
+		if _, err := svc.Create(context.Background(), appuser.CreateUserInput{Email: email, Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/export?name=ali&sort=name:desc", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; body: %s", rec.Code, rec.Body)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, `attachment; filename="users-`) || !strings.HasSuffix(cd, `.csv"`) {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, rec := range records[1:] {
+		names = append(names, rec[2])
+	}
+	if records[0][1] != "email" || fmt.Sprint(names) != "[Alicia Alice]" {
+		t.Errorf("header %v, names %v; want Alicia, Alice", records[0], names)
+	}
+}
+
+func TestHandler_ExportNDJSONStreamsEveryBatch(t *testing.T) {
+	router, svc := newDBRouter(t)
+
+	// More than one export batch, imported in import-sized chunks.
+	total := appuser.ExportBatchSize + 1
+	for start := 0; start < total; start += appuser.MaxImportBatch {
+		var rows []appuser.CreateUserInput
+		for i := start; i < min(start+appuser.MaxImportBatch, total); i++ {
+			rows = append(rows, appuser.CreateUserInput{Email: fmt.Sprintf("u%d@example.com", i), Name: "User"})
+		}
+		if _, err := svc.Import(context.Background(), rows); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/export?format=ndjson", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; body: %s", rec.Code, rec.Body)
+	}
+
+	seen := make(map[int64]bool)
+	sc := bufio.NewScanner(rec.Body)
+	for sc.Scan() {
+		var u appuser.UserDto
+		if err := json.Unmarshal(sc.Bytes(), &u); err != nil {
+			t.Fatal(err)
+		}
+		seen[u.Id] = true
+	}
+	if len(seen) != total {
+		t.Errorf("exported %d distinct users, want %d", len(seen), total)
+	}
+}
+
+func TestHandler_ExportRejectsUnknownFormat(t *testing.T) {
+	router, _ := newDBRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/export?format=xml", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
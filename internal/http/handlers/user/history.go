@@ -0,0 +1,82 @@
+package user
+
+import (
+	"fmt"
+	appuser "kabsa/internal/app/user"
+	domcommon "kabsa/internal/domain/common"
+	"kabsa/internal/http/responses"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// History godoc
+//
+//	@Summary		User change history
+//	@Description	Audited changes to the user, newest first. Available after the user is deleted.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id		path		int	true	"User ID"
+//	@Param			limit	query		int	false	"Page size (1-200)"	default(50)
+//	@Param			before	query		int	false	"Only entries older than this entry ID"
+//	@Success		200		{object}	apidocs.UserHistoryResponse
+//	@Failure		400		{object}	apidocs.Problem
+//	@Failure		404		{object}	apidocs.Problem
+//	@Failure		500		{object}	apidocs.Problem
+//	@Router			/users/{id}/history [get]
+func (h *Handler) History(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	input, err := parseHistoryQuery(r.URL.Query())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	page, err := h.service.History(ctx, id, input)
+	if err != nil {
+		h.writeError(w, r, err, "id", id)
+		return
+	}
+
+	links := PageLinks{Self: r.URL.RequestURI()}
+	if page.NextBefore != 0 {
+		q := r.URL.Query()
+		q.Set("limit", strconv.Itoa(page.Limit))
+		q.Set("before", strconv.FormatInt(page.NextBefore, 10))
+		links.Next = (&url.URL{Path: r.URL.Path, RawQuery: q.Encode()}).String()
+	}
+
+	responses.WriteJSON(w, http.StatusOK, HistoryResponse{
+		Data:  page.Items,
+		Limit: page.Limit,
+		Links: links,
+	})
+}
+
+func parseHistoryQuery(q url.Values) (appuser.HistoryInput, error) {
+	in := appuser.HistoryInput{Limit: appuser.DefaultListLimit}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > appuser.MaxListLimit {
+			return in, domcommon.NewFieldValidation("limit", "range",
+				fmt.Sprintf("must be an integer between 1 and %d", appuser.MaxListLimit))
+		}
+		in.Limit = n
+	}
+
+	if v := q.Get("before"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 {
+			return in, domcommon.NewFieldValidation("before", "min", "must be a positive integer")
+		}
+		in.Before = n
+	}
+	return in, nil
+}
@@ -1,13 +1,33 @@
 ﻿package user
 
+import (
+	appuser "kabsa/internal/app/user"
+	"kabsa/internal/http/responses"
+)
+
+// The request bodies only check that fields are present; the rules for
+// their values belong to the appuser inputs, which the handlers validate
+// before calling the service.
+
 type CreateUserRequest struct {
-	Email string `json:"email" validate:"required,email"`
-	Name  string `json:"name"  validate:"required,min=2,max=100"`
+	Email string `json:"email" validate:"required"`
+	Name  string `json:"name"  validate:"required"`
+}
+
+func (req CreateUserRequest) input() appuser.CreateUserInput {
+	return appuser.CreateUserInput{Email: req.Email, Name: req.Name}
 }
 
+// UpdateUserRequest is the PUT body: a full replacement, so every field is required.
 type UpdateUserRequest struct {
-	Name *string `json:"name" validate:"omitempty,min=2,max=100"`
-	// you can add more optional fields
+	Email string `json:"email" validate:"required"`
+	Name  string `json:"name"  validate:"required"`
+}
+
+// PatchUserRequest is the PATCH body (RFC 7396 merge patch): absent fields are left unchanged.
+type PatchUserRequest struct {
+	Email *string `json:"email"`
+	Name  *string `json:"name"`
 }
 
 type Response struct {
@@ -15,3 +35,69 @@ type Response struct {
 	Email string `json:"email"`
 	Name  string `json:"name"`
 }
+
+type PageLinks struct {
+	Self string `json:"self"`
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+type ListResponse struct {
+	Data       []appuser.UserDto `json:"data"`
+	Total      *int              `json:"total,omitempty"`
+	Limit      int               `json:"limit"`
+	Offset     int               `json:"offset"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+	Links      PageLinks         `json:"links"`
+}
+
+type HistoryResponse struct {
+	Data  []appuser.AuditEntryDto `json:"data"`
+	Limit int                     `json:"limit"`
+	Links PageLinks               `json:"links"`
+}
+
+// BatchRequest is the POST /users:batch body.
+type BatchRequest struct {
+	Operations []BatchOperation `json:"operations" validate:"required,min=1"`
+}
+
+// BatchOperation is one operation of a batch. Fields are checked per
+// operation, so one bad operation only fails itself.
+type BatchOperation struct {
+	Op      string  `json:"op"`                // create, update or delete
+	ID      int64   `json:"id,omitempty"`      // update and delete
+	Version *int64  `json:"version,omitempty"` // update and delete; like If-Match
+	Email   *string `json:"email,omitempty"`
+	Name    *string `json:"name,omitempty"`
+}
+
+// BatchResult is the outcome of Operations[Index]: the HTTP status the
+// operation would have had on its own, with the user or the problem.
+type BatchResult struct {
+	Index  int                `json:"index"`
+	Status int                `json:"status"`
+	Data   *appuser.UserDto   `json:"data,omitempty"`
+	Error  *responses.Problem `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// ImportError is why the row starting at Line was not imported.
+type ImportError struct {
+	Line    int                      `json:"line"`
+	Code    string                   `json:"code"`
+	Detail  string                   `json:"detail,omitempty"`
+	Details []responses.ProblemField `json:"details,omitempty"`
+}
+
+type ImportResponse struct {
+	Created         int           `json:"created"`
+	Failed          int           `json:"failed"`
+	Errors          []ImportError `json:"errors,omitempty"`
+	ErrorsTruncated bool          `json:"errorsTruncated,omitempty"`
+	// Error is set when the import stopped before the end of the file.
+	Error *responses.Problem `json:"error,omitempty"`
+}
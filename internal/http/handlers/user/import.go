@@ -0,0 +1,252 @@
+package user
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	appuser "kabsa/internal/app/user"
+	domcommon "kabsa/internal/domain/common"
+	"kabsa/internal/http/request"
+	"kabsa/internal/http/responses"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	// DefaultMaxImportBytes is used when Options.MaxImportBytes is not set.
+	DefaultMaxImportBytes int64 = 64 << 20 // 64 MiB
+
+	// maxImportErrors caps the errors listed in an import response; the
+	// failed count still covers every row.
+	maxImportErrors = 1000
+)
+
+// importRow is one data row of an import file and the line it started on.
+type importRow struct {
+	line int
+	user CreateUserRequest
+	err  error // set when the row could not be decoded
+}
+
+// importReader yields rows until io.EOF. Any other error means the file
+// cannot be read further.
+type importReader interface {
+	next() (importRow, error)
+}
+
+// Import godoc
+//
+//	@Summary		Import users from CSV or NDJSON
+//	@Description	Streams the body and creates users in batches. CSV needs a header row with email
+//	@Description	and name columns; NDJSON has one {"email","name"} object per line. Invalid rows
+//	@Description	and taken emails are reported by line and skipped. If the import stops early,
+//	@Description	the response carries the error and the rows before it stay imported.
+//	@Tags			users
+//	@Accept			text/csv
+//	@Accept			application/x-ndjson
+//	@Produce		json
+//	@Success		200	{object}	apidocs.UserImportResponse
+//	@Failure		400	{object}	apidocs.UserImportResponse
+//	@Failure		415	{object}	apidocs.Problem
+//	@Failure		500	{object}	apidocs.UserImportResponse
+//	@Router			/users:import [post]
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	body := http.MaxBytesReader(w, r.Body, h.maxImportBytes())
+
+	var rows importReader
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		rows = &csvImport{r: csv.NewReader(body)}
+	case "application/x-ndjson", "application/ndjson":
+		rows = &ndjsonImport{r: bufio.NewReader(body)}
+	default:
+		responses.WriteUnsupportedMediaType(w, r, "text/csv, application/x-ndjson")
+		return
+	}
+
+	var (
+		resp  ImportResponse
+		batch []importRow
+	)
+	fail := func(line int, err error) {
+		resp.Failed++
+		if len(resp.Errors) == maxImportErrors {
+			resp.ErrorsTruncated = true
+			return
+		}
+		p := responses.ProblemFor(err)
+		resp.Errors = append(resp.Errors, ImportError{Line: line, Code: p.Code, Detail: p.Detail, Details: p.Details})
+	}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		inputs := make([]appuser.CreateUserInput, len(batch))
+		for i, row := range batch {
+			inputs[i] = row.user.input()
+		}
+		results, err := h.service.Import(ctx, inputs)
+		if err != nil {
+			return err
+		}
+		for i, res := range results {
+			if res.Err != nil {
+				fail(batch[i].line, res.Err)
+			} else {
+				resp.Created++
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	err := func() error {
+		for {
+			row, err := rows.next()
+			if errors.Is(err, io.EOF) {
+				return flush()
+			}
+			if err != nil {
+				return err
+			}
+
+			if row.err == nil {
+				row.err = row.user.input().Validate()
+			}
+			if row.err != nil {
+				fail(row.line, row.err)
+				continue
+			}
+
+			if batch = append(batch, row); len(batch) == appuser.MaxImportBatch {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}()
+
+	status := http.StatusOK
+	if err != nil {
+		p := responses.ProblemFor(err)
+		if p.Status >= http.StatusInternalServerError {
+			h.logger.Error("user import failed", "error", err, "created", resp.Created)
+		}
+		p.Title = http.StatusText(p.Status)
+		p.Instance = r.URL.Path
+		status = p.Status
+		resp.Error = &p
+	}
+	responses.WriteJSON(w, status, resp)
+}
+
+func (h *Handler) maxImportBytes() int64 {
+	if h.opts.MaxImportBytes > 0 {
+		return h.opts.MaxImportBytes
+	}
+	return DefaultMaxImportBytes
+}
+
+type ndjsonImport struct {
+	r    *bufio.Reader
+	line int
+}
+
+func (n *ndjsonImport) next() (importRow, error) {
+	for {
+		data, err := n.r.ReadBytes('\n')
+		if err != nil && (len(data) == 0 || !errors.Is(err, io.EOF)) {
+			return importRow{}, readError(err)
+		}
+		n.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue // blank lines, including a trailing one, are not rows
+		}
+		row := importRow{line: n.line}
+		row.err = request.Unmarshal(data, &row.user)
+		return row, nil
+	}
+}
+
+type csvImport struct {
+	r       *csv.Reader
+	columns map[string]int // header name -> field index
+}
+
+func (c *csvImport) next() (importRow, error) {
+	if c.columns == nil {
+		if err := c.readHeader(); err != nil {
+			return importRow{}, err
+		}
+	}
+
+	record, err := c.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount) {
+			return importRow{line: parseErr.StartLine, err: domcommon.NewValidation(
+				fmt.Sprintf("row has %d fields, the header has %d", len(record), len(c.columns)))}, nil
+		}
+		return importRow{}, readError(err)
+	}
+
+	line, _ := c.r.FieldPos(0)
+	return importRow{line: line, user: CreateUserRequest{
+		Email: strings.TrimSpace(record[c.columns["email"]]),
+		Name:  strings.TrimSpace(record[c.columns["name"]]),
+	}}, nil
+}
+
+func (c *csvImport) readHeader() error {
+	header, err := c.r.Read()
+	if errors.Is(err, io.EOF) {
+		return domcommon.NewValidation("CSV body must start with a header row")
+	}
+	if err != nil {
+		return readError(err)
+	}
+
+	c.columns = make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheet exports often start with a UTF-8 byte order mark.
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		switch name {
+		case "email", "name":
+			c.columns[name] = i
+		default:
+			return domcommon.NewFieldValidation(name, "unknown", "unknown CSV column; expected email and name")
+		}
+	}
+	for _, name := range []string{"email", "name"} {
+		if _, ok := c.columns[name]; !ok {
+			return domcommon.NewFieldValidation(name, "required", "CSV header is missing the "+name+" column")
+		}
+	}
+	return nil
+}
+
+// readError turns a failure to read the body into a client error where it
+// is one: a malformed file or a body over the size limit.
+func readError(err error) error {
+	var (
+		tooLarge *http.MaxBytesError
+		parseErr *csv.ParseError
+	)
+	switch {
+	case errors.Is(err, io.EOF):
+		return io.EOF
+	case errors.As(err, &tooLarge):
+		return domcommon.NewValidation(fmt.Sprintf("request body must not exceed %d bytes", tooLarge.Limit))
+	case errors.As(err, &parseErr):
+		return domcommon.NewValidation(fmt.Sprintf("malformed CSV at line %d: %v", parseErr.Line, parseErr.Err))
+	}
+	return err
+}
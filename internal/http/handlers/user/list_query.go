@@ -0,0 +1,149 @@
+package user
+
+import (
+	"fmt"
+	appuser "kabsa/internal/app/user"
+	domcommon "kabsa/internal/domain/common"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var sortableFields = map[string]bool{
+	"id":         true,
+	"email":      true,
+	"name":       true,
+	"created_at": true,
+}
+
+// parseListQuery reads paging, sorting and filter params for GET /users.
+//
+//	limit          1..MaxListLimit (default DefaultListLimit)
+//	offset         >= 0
+//	cursor         nextCursor from a previous page; excludes offset
+//	sort           field[:asc|desc], field in id, email, name, created_at
+//	email          exact match (case-insensitive)
+//	name           substring match (case-insensitive)
+//	created_after  RFC 3339 timestamp
+//	created_before RFC 3339 timestamp
+//	include_deleted boolean; also list soft-deleted users
+func parseListQuery(q url.Values) (appuser.ListUsersInput, error) {
+	in := appuser.ListUsersInput{
+		Limit: appuser.DefaultListLimit,
+	}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > appuser.MaxListLimit {
+			return in, domcommon.NewFieldValidation("limit", "range",
+				fmt.Sprintf("must be an integer between 1 and %d", appuser.MaxListLimit))
+		}
+		in.Limit = n
+	}
+
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return in, domcommon.NewFieldValidation("offset", "min", "must be a non-negative integer")
+		}
+		in.Offset = n
+	}
+
+	if v := q.Get("cursor"); v != "" {
+		if q.Has("offset") {
+			return in, domcommon.NewFieldValidation("cursor", "excluded_with", "cannot be combined with offset")
+		}
+		in.Cursor = v
+	}
+
+	if v := q.Get("sort"); v != "" {
+		field, dir, _ := strings.Cut(v, ":")
+		if !sortableFields[field] {
+			return in, domcommon.NewFieldValidation("sort", "oneof", "field must be one of id, email, name, created_at")
+		}
+		switch strings.ToLower(dir) {
+		case "", "asc":
+		case "desc":
+			in.SortDesc = true
+		default:
+			return in, domcommon.NewFieldValidation("sort", "oneof", "direction must be asc or desc")
+		}
+		in.SortBy = field
+	}
+
+	in.Email = strings.TrimSpace(q.Get("email"))
+	in.NameContains = strings.TrimSpace(q.Get("name"))
+
+	var err error
+	if in.CreatedAfter, err = parseTimeParam(q, "created_after"); err != nil {
+		return in, err
+	}
+	if in.CreatedBefore, err = parseTimeParam(q, "created_before"); err != nil {
+		return in, err
+	}
+	if in.CreatedAfter != nil && in.CreatedBefore != nil && !in.CreatedAfter.Before(*in.CreatedBefore) {
+		return in, domcommon.NewFieldValidation("created_after", "ltfield", "must be before created_before")
+	}
+
+	if v := q.Get("include_deleted"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return in, domcommon.NewFieldValidation("include_deleted", "boolean", "must be true or false")
+		}
+		in.IncludeDeleted = b
+	}
+
+	return in, nil
+}
+
+func parseTimeParam(q url.Values, name string) (*time.Time, error) {
+	v := q.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, domcommon.NewFieldValidation(name, "datetime", "must be an RFC 3339 timestamp")
+	}
+	return &t, nil
+}
+
+// pageLinks builds self/next/prev links for a page, keeping all other query
+// params. Offset pages link by offset; cursor pages only link forward.
+func pageLinks(r *http.Request, page *appuser.UserListDto) PageLinks {
+	link := func(set func(q url.Values)) string {
+		q := r.URL.Query()
+		q.Set("limit", strconv.Itoa(page.Limit))
+		set(q)
+		u := url.URL{Path: r.URL.Path, RawQuery: q.Encode()}
+		return u.String()
+	}
+	atOffset := func(offset int) string {
+		return link(func(q url.Values) {
+			q.Del("cursor")
+			q.Set("offset", strconv.Itoa(offset))
+		})
+	}
+
+	if page.Total == nil {
+		links := PageLinks{Self: link(func(url.Values) {})}
+		if page.NextCursor != "" {
+			links.Next = link(func(q url.Values) {
+				q.Del("offset")
+				q.Set("cursor", page.NextCursor)
+			})
+		}
+		return links
+	}
+
+	links := PageLinks{Self: atOffset(page.Offset)}
+	if next := page.Offset + page.Limit; next < *page.Total {
+		links.Next = atOffset(next)
+	}
+	if page.Offset > 0 {
+		links.Prev = atOffset(max(page.Offset-page.Limit, 0))
+	}
+	return links
+}
@@ -0,0 +1,180 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	appuser "kabsa/internal/app/user"
+	domcommon "kabsa/internal/domain/common"
+)
+
+func TestParseListQuery(t *testing.T) {
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query string
+		want  appuser.ListUsersInput
+		field string // rejected field; empty means the query is valid
+	}{
+		{name: "defaults", want: appuser.ListUsersInput{Limit: appuser.DefaultListLimit}},
+		{name: "limit", query: "limit=10", want: appuser.ListUsersInput{Limit: 10}},
+		{name: "limit at max", query: "limit=200", want: appuser.ListUsersInput{Limit: appuser.MaxListLimit}},
+		{name: "limit above max", query: "limit=201", field: "limit"},
+		{name: "limit zero", query: "limit=0", field: "limit"},
+		{name: "limit not a number", query: "limit=ten", field: "limit"},
+		{name: "offset", query: "offset=20", want: appuser.ListUsersInput{Limit: appuser.DefaultListLimit, Offset: 20}},
+		{name: "offset negative", query: "offset=-1", field: "offset"},
+		{name: "cursor", query: "cursor=abc", want: appuser.ListUsersInput{Limit: appuser.DefaultListLimit, Cursor: "abc"}},
+		{name: "cursor with offset", query: "cursor=abc&offset=0", field: "cursor"},
+		{name: "sort field", query: "sort=email", want: appuser.ListUsersInput{Limit: appuser.DefaultListLimit, SortBy: "email"}},
+		{name: "sort asc", query: "sort=name:asc", want: appuser.ListUsersInput{Limit: appuser.DefaultListLimit, SortBy: "name"}},
+		{
+			name: "sort desc", query: "sort=created_at:DESC",
+			want: appuser.ListUsersInput{Limit: appuser.DefaultListLimit, SortBy: "created_at", SortDesc: true},
+		},
+		{name: "sort unknown field", query: "sort=password", field: "sort"},
+		{name: "sort unknown direction", query: "sort=id:up", field: "sort"},
+		{
+			name: "filters trimmed", query: "email=+jane@example.com+&name=+Jane+",
+			want: appuser.ListUsersInput{Limit: appuser.DefaultListLimit, Email: "jane@example.com", NameContains: "Jane"},
+		},
+		{
+			name: "created range", query: "created_after=2026-01-01T00:00:00Z&created_before=2026-02-01T00:00:00Z",
+			want: appuser.ListUsersInput{Limit: appuser.DefaultListLimit, CreatedAfter: &after, CreatedBefore: &before},
+		},
+		{name: "created_after malformed", query: "created_after=2026-01-01", field: "created_after"},
+		{name: "created_before malformed", query: "created_before=yesterday", field: "created_before"},
+		{
+			name:  "created range inverted",
+			query: "created_after=2026-02-01T00:00:00Z&created_before=2026-01-01T00:00:00Z", field: "created_after",
+		},
+		{
+			name:  "created range empty",
+			query: "created_after=2026-01-01T00:00:00Z&created_before=2026-01-01T00:00:00Z", field: "created_after",
+		},
+		{name: "include_deleted malformed", query: "include_deleted=maybe", field: "include_deleted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parseListQuery(q)
+
+			if tt.field != "" {
+				var verr domcommon.ValidationError
+				if !errors.As(err, &verr) {
+					t.Fatalf("err = %v, want a validation error", err)
+				}
+				if len(verr.Fields) != 1 || verr.Fields[0].Field != tt.field {
+					t.Fatalf("fields = %+v, want one error on %q", verr.Fields, tt.field)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equalListInput(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func equalListInput(a, b appuser.ListUsersInput) bool {
+	sameTime := func(x, y *time.Time) bool {
+		if x == nil || y == nil {
+			return x == y
+		}
+		return x.Equal(*y)
+	}
+	if !sameTime(a.CreatedAfter, b.CreatedAfter) || !sameTime(a.CreatedBefore, b.CreatedBefore) {
+		return false
+	}
+	a.CreatedAfter, a.CreatedBefore, b.CreatedAfter, b.CreatedBefore = nil, nil, nil, nil
+	return a == b
+}
+
+func TestPageLinks(t *testing.T) {
+	total := func(n int) *int { return &n }
+
+	tests := []struct {
+		name   string
+		target string
+		page   appuser.UserListDto
+		want   PageLinks
+	}{
+		{
+			name:   "first offset page",
+			target: "/users?name=jane",
+			page:   appuser.UserListDto{Total: total(25), Limit: 10},
+			want: PageLinks{
+				Self: "/users?limit=10&name=jane&offset=0",
+				Next: "/users?limit=10&name=jane&offset=10",
+			},
+		},
+		{
+			name:   "middle offset page",
+			target: "/users?offset=10&limit=10",
+			page:   appuser.UserListDto{Total: total(25), Limit: 10, Offset: 10},
+			want: PageLinks{
+				Self: "/users?limit=10&offset=10",
+				Next: "/users?limit=10&offset=20",
+				Prev: "/users?limit=10&offset=0",
+			},
+		},
+		{
+			name:   "last offset page",
+			target: "/users?offset=20&limit=10",
+			page:   appuser.UserListDto{Total: total(25), Limit: 10, Offset: 20},
+			want: PageLinks{
+				Self: "/users?limit=10&offset=20",
+				Prev: "/users?limit=10&offset=10",
+			},
+		},
+		{
+			name:   "prev clamps to zero",
+			target: "/users?offset=5&limit=10",
+			page:   appuser.UserListDto{Total: total(25), Limit: 10, Offset: 5},
+			want: PageLinks{
+				Self: "/users?limit=10&offset=5",
+				Next: "/users?limit=10&offset=15",
+				Prev: "/users?limit=10&offset=0",
+			},
+		},
+		{
+			name:   "cursor page with more",
+			target: "/users?sort=email&cursor=abc",
+			page:   appuser.UserListDto{Limit: 50, NextCursor: "def"},
+			want: PageLinks{
+				Self: "/users?cursor=abc&limit=50&sort=email",
+				Next: "/users?cursor=def&limit=50&sort=email",
+			},
+		},
+		{
+			name:   "last cursor page",
+			target: "/users?cursor=abc",
+			page:   appuser.UserListDto{Limit: 50},
+			want:   PageLinks{Self: "/users?cursor=abc&limit=50"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+			if got := pageLinks(r, &tt.page); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
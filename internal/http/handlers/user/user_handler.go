@@ -1,8 +1,9 @@
 package user
 
 import (
-	"encoding/json"
 	appuser "kabsa/internal/app/user"
+	domcommon "kabsa/internal/domain/common"
+	"kabsa/internal/http/request"
 	"kabsa/internal/http/responses"
 	"kabsa/internal/logging"
 	"net/http"
@@ -13,12 +14,26 @@ import (
 
 type Handler struct {
 	service appuser.Service
+	opts    Options
 	logger  logging.Logger
 }
 
-func NewHandler(service appuser.Service, logger logging.Logger) *Handler {
+// Options tunes handler behaviour that differs between deployments.
+type Options struct {
+	// RequireIfMatch rejects PUT/PATCH/DELETE without If-Match with 428.
+	RequireIfMatch bool
+	// ExposeDeleted allows include_deleted and restore; without it they get 403.
+	ExposeDeleted bool
+	// MaxBatchSize caps operations per POST /users:batch (DefaultMaxBatchSize if 0).
+	MaxBatchSize int
+	// MaxImportBytes caps POST /users:import bodies (DefaultMaxImportBytes if 0).
+	MaxImportBytes int64
+}
+
+func NewHandler(service appuser.Service, opts Options, logger logging.Logger) *Handler {
 	return &Handler{
 		service: service,
+		opts:    opts,
 		logger:  logger.With("component", "user_http_handler"),
 	}
 }
@@ -28,23 +43,46 @@ func NewHandler(service appuser.Service, logger logging.Logger) *Handler {
 //	@Summary	List users
 //	@Tags		users
 //	@Produce	json
-//	@Success	200	{object}	apidocs.UsersListResponse
-//	@Failure	500	{object}	apidocs.ErrorEnvelope
+//	@Param		limit			query		int		false	"Page size (1-200)"	default(50)
+//	@Param		offset			query		int		false	"Number of rows to skip"	default(0)
+//	@Param		cursor			query		string	false	"Opaque nextCursor from a previous page (keyset paging)"
+//	@Param		sort			query		string	false	"Sort as field[:asc|desc]; field is id, email, name or created_at"
+//	@Param		email			query		string	false	"Exact email match (case-insensitive)"
+//	@Param		name			query		string	false	"Name contains (case-insensitive)"
+//	@Param		created_after	query		string	false	"Created after (RFC 3339)"
+//	@Param		created_before	query		string	false	"Created before (RFC 3339)"
+//	@Param		include_deleted	query		bool	false	"Admin: also list soft-deleted users"	default(false)
+//	@Success	200				{object}	apidocs.UsersListResponse
+//	@Failure	400				{object}	apidocs.Problem
+//	@Failure	403				{object}	apidocs.Problem	"include_deleted is disabled"
+//	@Failure	500				{object}	apidocs.Problem
 //	@Router		/users [get]
 func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	users, err := h.service.List(ctx, appuser.ListUsersInput{
-		Limit:  50, // TODO: pull from query params
-		Offset: 0,
-	})
+	input, err := parseListQuery(r.URL.Query())
+	if err == nil {
+		err = h.checkDeletedAccess(input.IncludeDeleted)
+	}
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	page, err := h.service.List(ctx, input)
 	if err != nil {
-		h.logger.Error("failed to list users", "error", err)
-		responses.WriteError(w, http.StatusInternalServerError, "internal server error")
+		h.writeError(w, r, err)
 		return
 	}
 
-	responses.WriteJSON(w, http.StatusOK, users)
+	responses.WriteJSON(w, http.StatusOK, ListResponse{
+		Data:       page.Items,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+		Links:      pageLinks(r, page),
+	})
 }
 
 // Create godoc
@@ -55,32 +93,38 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 //	@Produce	json
 //	@Param		body	body		user.CreateUserRequest	true	"Create payload"
 //	@Success	201		{object}	apidocs.UserItemResponse
-//	@Failure	400		{object}	apidocs.ErrorEnvelope
-//	@Failure	500		{object}	apidocs.ErrorEnvelope
+//	@Failure	400		{object}	apidocs.Problem
+//	@Failure	409		{object}	apidocs.Problem	"Email already taken"
+//	@Failure	415		{object}	apidocs.Problem
+//	@Failure	500		{object}	apidocs.Problem
 //	@Router		/users [post]
 func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var input struct {
-		Email string `json:"email"`
-		Name  string `json:"name"`
+	if !request.IsJSON(r) {
+		responses.WriteUnsupportedMediaType(w, r, request.JSONContentType)
+		return
 	}
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		h.logger.Error("invalid create user payload", "error", err)
-		responses.WriteError(w, http.StatusBadRequest, "invalid request body")
+
+	var input CreateUserRequest
+	if err := request.Bind(w, r, &input); err != nil {
+		h.writeError(w, r, err)
 		return
 	}
 
-	dto, err := h.service.Create(ctx, appuser.CreateUserInput{
-		Email: input.Email,
-		Name:  input.Name,
-	})
+	in := input.input()
+	if err := in.Validate(); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	dto, err := h.service.Create(ctx, in)
 	if err != nil {
-		h.logger.Error("failed to create user", "error", err)
-		responses.WriteError(w, http.StatusInternalServerError, "internal server error")
+		h.writeError(w, r, err)
 		return
 	}
 
+	w.Header().Set("ETag", etagFor(dto.Version))
 	responses.WriteJSON(w, http.StatusCreated, dto)
 }
 
@@ -89,28 +133,33 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 //	@Summary	Get user by ID
 //	@Tags		users
 //	@Produce	json
-//	@Param		id	path		int	true	"User ID"
-//	@Success	200	{object}	apidocs.UserItemResponse
-//	@Failure	404	{object}	apidocs.ErrorEnvelope
-//	@Failure	500	{object}	apidocs.ErrorEnvelope
+//	@Param		id				path		int		true	"User ID"
+//	@Param		If-None-Match	header		string	false	"ETag from a previous GET"
+//	@Success	200				{object}	apidocs.UserItemResponse
+//	@Header		200				{string}	ETag	"Current version of the user"
+//	@Success	304				{string}	string	"Not Modified"
+//	@Failure	400				{object}	apidocs.Problem
+//	@Failure	404				{object}	apidocs.Problem
+//	@Failure	500				{object}	apidocs.Problem
 //	@Router		/users/{id} [get]
 func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	idStr := chi.URLParam(r, "id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+	id, err := parseID(r)
 	if err != nil {
-		responses.WriteError(w, http.StatusBadRequest, "invalid user id")
+		h.writeError(w, r, err)
 		return
 	}
 
 	dto, err := h.service.GetById(ctx, id)
 	if err != nil {
-		if appuser.IsNotFound(err) {
-			responses.WriteError(w, http.StatusNotFound, "user not found")
-			return
-		}
-		h.logger.Error("failed to get user", "error", err, "id", id)
-		responses.WriteError(w, http.StatusInternalServerError, "internal server error")
+		h.writeError(w, r, err, "id", id)
+		return
+	}
+
+	etag := etagFor(dto.Version)
+	w.Header().Set("ETag", etag)
+	if tags, ok := request.IfNoneMatch(r); ok && tags.MatchWeak(etag) {
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
@@ -119,80 +168,248 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 
 // Update godoc
 //
-//	@Summary	Update user
-//	@Tags		users
-//	@Accept		json
-//	@Produce	json
-//	@Param		id		path		int								true	"User ID"
-//	@Param		body	body		user.UpdateUserRequest	true	"Update payload"
-//	@Success	200		{object}	apidocs.UserItemResponse
-//	@Failure	400		{object}	apidocs.ErrorEnvelope
-//	@Failure	404		{object}	apidocs.ErrorEnvelope
-//	@Failure	500		{object}	apidocs.ErrorEnvelope
-//	@Router		/users/{id} [put]
+//	@Summary		Replace user
+//	@Description	Full replacement: every field must be provided.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int						true	"User ID"
+//	@Param			If-Match	header		string					false	"ETag from a previous GET"
+//	@Param			body		body		user.UpdateUserRequest	true	"Replacement payload"
+//	@Success		200			{object}	apidocs.UserItemResponse
+//	@Header			200			{string}	ETag	"New version of the user"
+//	@Failure		400			{object}	apidocs.Problem
+//	@Failure		404			{object}	apidocs.Problem
+//	@Failure		409			{object}	apidocs.Problem	"Email already taken"
+//	@Failure		412			{object}	apidocs.Problem	"If-Match does not match the current version"
+//	@Failure		415			{object}	apidocs.Problem
+//	@Failure		428			{object}	apidocs.Problem	"If-Match is required"
+//	@Failure		500			{object}	apidocs.Problem
+//	@Router			/users/{id} [put]
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	idStr := chi.URLParam(r, "id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+	id, err := parseID(r)
 	if err != nil {
-		responses.WriteError(w, http.StatusBadRequest, "invalid user id")
+		h.writeError(w, r, err)
 		return
 	}
 
-	var input struct {
-		Name *string `json:"name"`
+	if !request.IsJSON(r) {
+		responses.WriteUnsupportedMediaType(w, r, request.JSONContentType)
+		return
 	}
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		h.logger.Error("invalid update user payload", "error", err)
-		responses.WriteError(w, http.StatusBadRequest, "invalid request body")
+
+	expected, err := h.expectedVersion(r, id)
+	if err != nil {
+		h.writeError(w, r, err)
 		return
 	}
 
-	dto, err := h.service.Update(ctx, appuser.UpdateUserInput{
-		ID:   id,
-		Name: input.Name,
-	})
+	var input UpdateUserRequest
+	if err := request.Bind(w, r, &input); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	in := appuser.UpdateUserInput{
+		ID:              id,
+		Email:           &input.Email,
+		Name:            &input.Name,
+		ExpectedVersion: expected,
+	}
+	if err := in.Validate(); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	dto, err := h.service.Update(ctx, in)
 	if err != nil {
-		if appuser.IsNotFound(err) {
-			responses.WriteError(w, http.StatusNotFound, "user not found")
-			return
+		h.writeError(w, r, err, "id", id)
+		return
+	}
+
+	w.Header().Set("ETag", etagFor(dto.Version))
+	responses.WriteJSON(w, http.StatusOK, dto)
+}
+
+// Patch godoc
+//
+//	@Summary		Patch user
+//	@Description	RFC 7396 JSON merge patch: only the fields present are changed.
+//	@Tags			users
+//	@Accept			application/merge-patch+json
+//	@Produce		json
+//	@Param			id			path		int						true	"User ID"
+//	@Param			If-Match	header		string					false	"ETag from a previous GET"
+//	@Param			body		body		user.PatchUserRequest	true	"Merge patch"
+//	@Success		200			{object}	apidocs.UserItemResponse
+//	@Header			200			{string}	ETag	"New version of the user"
+//	@Failure		400			{object}	apidocs.Problem
+//	@Failure		404			{object}	apidocs.Problem
+//	@Failure		409			{object}	apidocs.Problem	"Email already taken"
+//	@Failure		412			{object}	apidocs.Problem	"If-Match does not match the current version"
+//	@Failure		415			{object}	apidocs.Problem
+//	@Failure		428			{object}	apidocs.Problem	"If-Match is required"
+//	@Failure		500			{object}	apidocs.Problem
+//	@Router			/users/{id} [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if !request.IsMergePatch(r) {
+		responses.WriteUnsupportedMediaType(w, r, request.MergePatchContentType)
+		return
+	}
+
+	expected, err := h.expectedVersion(r, id)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	var input PatchUserRequest
+	nulls, err := request.BindMergePatch(w, r, &input)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	// null removes a member in merge-patch, but no user field is optional.
+	if len(nulls) > 0 {
+		fields := make([]domcommon.FieldError, 0, len(nulls))
+		for _, name := range nulls {
+			fields = append(fields, domcommon.FieldError{Field: name, Rule: "required", Message: name + " cannot be removed"})
 		}
-		h.logger.Error("failed to update user", "error", err, "id", id)
-		responses.WriteError(w, http.StatusInternalServerError, "internal server error")
+		h.writeError(w, r, domcommon.NewValidation("request validation failed", fields...))
+		return
+	}
+
+	in := appuser.UpdateUserInput{
+		ID:              id,
+		Email:           input.Email,
+		Name:            input.Name,
+		ExpectedVersion: expected,
+	}
+	if err := in.Validate(); err != nil {
+		h.writeError(w, r, err)
 		return
 	}
 
+	dto, err := h.service.Update(ctx, in)
+	if err != nil {
+		h.writeError(w, r, err, "id", id)
+		return
+	}
+
+	w.Header().Set("ETag", etagFor(dto.Version))
 	responses.WriteJSON(w, http.StatusOK, dto)
 }
 
 // Delete godoc
 //
-//	@Summary	Delete user
+//	@Summary		Delete user
+//	@Description	Soft-deletes the user: it disappears from reads and can be restored
+//	@Description	until it is purged after the retention period.
 //	@Tags		users
 //	@Produce	json
-//	@Param		id	path		int		true	"User ID"
-//	@Success	204	{string}	string	"No Content"
-//	@Failure	404	{object}	apidocs.ErrorEnvelope
-//	@Failure	500	{object}	apidocs.ErrorEnvelope
+//	@Param		id			path		int		true	"User ID"
+//	@Param		If-Match	header		string	false	"ETag from a previous GET"
+//	@Success	204			{string}	string	"No Content"
+//	@Failure	400			{object}	apidocs.Problem
+//	@Failure	404			{object}	apidocs.Problem
+//	@Failure	412			{object}	apidocs.Problem	"If-Match does not match the current version"
+//	@Failure	428			{object}	apidocs.Problem	"If-Match is required"
+//	@Failure	500			{object}	apidocs.Problem
 //	@Router		/users/{id} [delete]
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	idStr := chi.URLParam(r, "id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+	id, err := parseID(r)
 	if err != nil {
-		responses.WriteError(w, http.StatusBadRequest, "invalid user id")
+		h.writeError(w, r, err)
 		return
 	}
 
-	if err := h.service.Delete(ctx, id); err != nil {
-		if appuser.IsNotFound(err) {
-			responses.WriteError(w, http.StatusNotFound, "user not found")
-			return
-		}
-		h.logger.Error("failed to delete user", "error", err, "id", id)
-		responses.WriteError(w, http.StatusInternalServerError, "internal server error")
+	expected, err := h.expectedVersion(r, id)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := h.service.Delete(ctx, appuser.DeleteUserInput{ID: id, ExpectedVersion: expected}); err != nil {
+		h.writeError(w, r, err, "id", id)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// Restore godoc
+//
+//	@Summary		Restore deleted user
+//	@Description	Undoes a soft delete. Restoring a user that isn't deleted returns it unchanged.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		int	true	"User ID"
+//	@Success		200	{object}	apidocs.UserItemResponse
+//	@Header			200	{string}	ETag	"New version of the user"
+//	@Failure		400	{object}	apidocs.Problem
+//	@Failure		403	{object}	apidocs.Problem	"Restoring is disabled"
+//	@Failure		404	{object}	apidocs.Problem	"User never existed or was already purged"
+//	@Failure		500	{object}	apidocs.Problem
+//	@Router			/users/{id}/restore [post]
+func (h *Handler) Restore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if err := h.checkDeletedAccess(true); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	dto, err := h.service.Restore(ctx, id)
+	if err != nil {
+		h.writeError(w, r, err, "id", id)
+		return
+	}
+
+	w.Header().Set("ETag", etagFor(dto.Version))
+	responses.WriteJSON(w, http.StatusOK, dto)
+}
+
+var errDeletedHidden = domcommon.NewForbidden("soft-deleted users are not exposed by this API")
+
+// checkDeletedAccess gates every way of reaching soft-deleted users.
+func (h *Handler) checkDeletedAccess(deleted bool) error {
+	if deleted && !h.opts.ExposeDeleted {
+		return errDeletedHidden
+	}
+	return nil
+}
+
+func parseID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, domcommon.NewFieldValidation("id", "int64", "must be a positive integer")
+	}
+	return id, nil
+}
+
+// writeError renders err as problem+json and logs it when it maps to a 5xx;
+// client errors are already visible in the request log.
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error, kv ...any) {
+	if status := responses.StatusFor(err); status >= http.StatusInternalServerError {
+		h.logger.Error("request failed", append([]any{
+			"error", err,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", status,
+		}, kv...)...)
+	}
+	responses.WriteError(w, r, err)
+}
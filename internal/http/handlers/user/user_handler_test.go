@@ -0,0 +1,453 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	appuser "kabsa/internal/app/user"
+	dom "kabsa/internal/domain/user"
+	"kabsa/internal/http/request"
+	"kabsa/internal/http/responses"
+	"kabsa/internal/logging"
+	"kabsa/internal/pagination"
+)
+
+// emptyRepo behaves like a repository with no users in it.
+type emptyRepo struct{}
+
+func (emptyRepo) GetById(context.Context, int64) (*dom.User, error)     { return nil, dom.ErrNotFound }
+func (emptyRepo) GetByEmail(context.Context, string) (*dom.User, error) { return nil, dom.ErrNotFound }
+func (emptyRepo) List(context.Context, dom.ListFilter) ([]dom.User, error) {
+	return nil, nil
+}
+func (emptyRepo) Count(context.Context, dom.ListFilter) (int, error) { return 0, nil }
+func (emptyRepo) Create(context.Context, *dom.User) error            { return nil }
+func (emptyRepo) CreateBulk(context.Context, []*dom.User) error      { return nil }
+func (emptyRepo) TakenEmails(context.Context, []string) ([]string, error) {
+	return nil, nil
+}
+func (emptyRepo) Update(context.Context, *dom.User) error           { return dom.ErrNotFound }
+func (emptyRepo) Delete(context.Context, int64, int64) error        { return dom.ErrNotFound }
+func (emptyRepo) Restore(context.Context, int64) (*dom.User, error) { return nil, dom.ErrNotFound }
+func (emptyRepo) Purge(context.Context, time.Time, int) ([]dom.User, error) {
+	return nil, nil
+}
+
+// missCache never has anything cached.
+type missCache struct{}
+
+func (missCache) GetByID(context.Context, int64) ([]byte, error)          { return nil, nil }
+func (missCache) Set(context.Context, int64, []byte, time.Duration) error { return nil }
+func (missCache) Delete(context.Context, int64) error                     { return nil }
+
+func newTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+
+	codec, err := pagination.NewCursorCodec("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := appuser.NewService(emptyRepo{}, missCache{}, nil, appuser.NoopEvents{}, nil, codec, logging.NewNop())
+	h := NewHandler(svc, Options{ExposeDeleted: true}, logging.NewNop())
+
+	r := chi.NewRouter()
+	r.Get("/users/{id}", h.GetByID)
+	r.Put("/users/{id}", h.Update)
+	r.Patch("/users/{id}", h.Patch)
+	r.Delete("/users/{id}", h.Delete)
+	r.Post("/users/{id}/restore", h.Restore)
+	r.Get("/users/{id}/history", h.History)
+	return r
+}
+
+func TestHandler_MissingUserReturns404(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "get", method: http.MethodGet},
+		{name: "update", method: http.MethodPut, body: `{"email":"jane@example.com","name":"Jane Doe"}`},
+		{name: "patch", method: http.MethodPatch, body: `{"name":"Jane Doe"}`},
+		{name: "delete", method: http.MethodDelete},
+		{name: "restore", method: http.MethodPost, path: "/users/42/restore"},
+		{name: "history", method: http.MethodGet, path: "/users/42/history"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path
+			if path == "" {
+				path = "/users/42"
+			}
+			req := httptest.NewRequest(tt.method, path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNotFound {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusNotFound, rec.Body)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != responses.ProblemContentType {
+				t.Errorf("Content-Type = %q, want %q", ct, responses.ProblemContentType)
+			}
+
+			var p responses.Problem
+			if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if p.Code != responses.CodeNotFound {
+				t.Errorf("code = %q, want %q", p.Code, responses.CodeNotFound)
+			}
+		})
+	}
+}
+
+func TestHandler_Preconditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		method  string
+		header  string
+		value   func(etag string) string
+		body    string
+		status  int
+		code    string
+		changed bool
+	}{
+		{name: "get returns etag", method: http.MethodGet, status: http.StatusOK},
+		{
+			name: "if-none-match current", method: http.MethodGet,
+			header: "If-None-Match", value: func(etag string) string { return etag },
+			status: http.StatusNotModified,
+		},
+		{
+			name: "if-none-match weak current", method: http.MethodGet,
+			header: "If-None-Match", value: func(etag string) string { return "W/" + etag },
+			status: http.StatusNotModified,
+		},
+		{
+			name: "if-none-match stale", method: http.MethodGet,
+			header: "If-None-Match", value: func(string) string { return `"999"` },
+			status: http.StatusOK,
+		},
+		{
+			name: "if-match current", method: http.MethodPatch,
+			header: "If-Match", value: func(etag string) string { return etag },
+			body: `{"name":"Jane Roe"}`, status: http.StatusOK, changed: true,
+		},
+		{
+			name: "if-match stale", method: http.MethodPatch,
+			header: "If-Match", value: func(string) string { return `"999"` },
+			body: `{"name":"Jane Roe"}`, status: http.StatusPreconditionFailed, code: responses.CodeStale,
+		},
+		{
+			name: "if-match list with current", method: http.MethodPatch,
+			header: "If-Match", value: func(etag string) string { return `"998", ` + etag + `, "999"` },
+			body: `{"name":"Jane Roe"}`, status: http.StatusOK, changed: true,
+		},
+		{
+			name: "if-match list without current", method: http.MethodPatch,
+			header: "If-Match", value: func(string) string { return `"998", "999"` },
+			body: `{"name":"Jane Roe"}`, status: http.StatusPreconditionFailed, code: responses.CodeStale,
+		},
+		{
+			name: "if-match weak never matches", method: http.MethodPatch,
+			header: "If-Match", value: func(etag string) string { return "W/" + etag },
+			body: `{"name":"Jane Roe"}`, status: http.StatusPreconditionFailed, code: responses.CodeStale,
+		},
+		{
+			name: "if-match any", method: http.MethodDelete,
+			header: "If-Match", value: func(string) string { return "*" },
+			status: http.StatusNoContent, changed: true,
+		},
+		{
+			name: "if-match stale delete", method: http.MethodDelete,
+			header: "If-Match", value: func(string) string { return `"999"` },
+			status: http.StatusPreconditionFailed, code: responses.CodeStale,
+		},
+		{
+			name: "if-match required", opts: Options{RequireIfMatch: true}, method: http.MethodPut,
+			body:   `{"email":"jane@example.com","name":"Jane Roe"}`,
+			status: http.StatusPreconditionRequired, code: responses.CodeNoPrecond,
+		},
+		{
+			name: "if-match required and sent", opts: Options{RequireIfMatch: true}, method: http.MethodPut,
+			header: "If-Match", value: func(etag string) string { return etag },
+			body:   `{"email":"jane@example.com","name":"Jane Roe"}`,
+			status: http.StatusOK, changed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newDBService(t, appuser.NoopEvents{})
+			created, err := svc.Create(context.Background(), appuser.CreateUserInput{Email: "jane@example.com", Name: "Jane Doe"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			etag := etagFor(created.Version)
+			path := "/users/" + strconv.FormatInt(created.Id, 10)
+
+			h := NewHandler(svc, tt.opts, logging.NewNop())
+			r := chi.NewRouter()
+			r.Get("/users/{id}", h.GetByID)
+			r.Put("/users/{id}", h.Update)
+			r.Patch("/users/{id}", h.Patch)
+			r.Delete("/users/{id}", h.Delete)
+
+			req := httptest.NewRequest(tt.method, path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value(etag))
+			}
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.method == http.MethodGet {
+				if got := rec.Header().Get("ETag"); got != etag {
+					t.Errorf("ETag = %q, want %q", got, etag)
+				}
+			}
+			if tt.code != "" {
+				var p responses.Problem
+				if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+					t.Fatalf("decode problem: %v", err)
+				}
+				if p.Code != tt.code {
+					t.Errorf("code = %q, want %q", p.Code, tt.code)
+				}
+			}
+
+			after, err := svc.GetById(context.Background(), created.Id)
+			deleted := errors.Is(err, dom.ErrNotFound)
+			if err != nil && !deleted {
+				t.Fatal(err)
+			}
+			if changed := deleted || after.Version != created.Version; changed != tt.changed {
+				t.Errorf("user changed = %v, want %v", changed, tt.changed)
+			}
+		})
+	}
+}
+
+func TestHandler_RejectsNonJSONBodies(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+	}{
+		{name: "update form", method: http.MethodPut, contentType: "application/x-www-form-urlencoded"},
+		{name: "update without type", method: http.MethodPut},
+		{name: "patch text", method: http.MethodPatch, contentType: "text/plain"},
+		{name: "patch json patch", method: http.MethodPatch, contentType: "application/json-patch+json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/users/42", strings.NewReader(`{"email":"jane@example.com","name":"Jane Doe"}`))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusUnsupportedMediaType {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusUnsupportedMediaType, rec.Body)
+			}
+			var p responses.Problem
+			if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if p.Code != responses.CodeMediaType {
+				t.Errorf("code = %q, want %q", p.Code, responses.CodeMediaType)
+			}
+			wantAccept := ""
+			if tt.method == http.MethodPatch {
+				wantAccept = request.MergePatchContentType
+			}
+			if got := rec.Header().Get("Accept-Patch"); got != wantAccept {
+				t.Errorf("Accept-Patch = %q, want %q", got, wantAccept)
+			}
+		})
+	}
+}
+
+// emailEvents records UserEmailChanged events.
+type emailEvents struct {
+	appuser.NoopEvents
+	changes []string
+}
+
+func (e *emailEvents) UserEmailChanged(_ context.Context, id int64, oldEmail, newEmail string) error {
+	e.changes = append(e.changes, fmt.Sprintf("%d:%s->%s", id, oldEmail, newEmail))
+	return nil
+}
+
+func TestHandler_Patch(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		status  int
+		details []responses.ProblemField
+		want    appuser.UserDto
+		changes bool
+	}{
+		{
+			name: "name only", body: `{"name":"Jane Roe"}`, status: http.StatusOK,
+			want: appuser.UserDto{Email: "jane@example.com", Name: "Jane Roe"},
+		},
+		{
+			name: "email change", body: `{"email":"roe@example.com"}`, status: http.StatusOK,
+			want: appuser.UserDto{Email: "roe@example.com", Name: "Jane Doe"}, changes: true,
+		},
+		{
+			name: "same email", body: `{"email":"jane@example.com","name":"Jane Roe"}`, status: http.StatusOK,
+			want: appuser.UserDto{Email: "jane@example.com", Name: "Jane Roe"},
+		},
+		{
+			name: "email taken", body: `{"email":"taken@example.com"}`, status: http.StatusConflict,
+			details: []responses.ProblemField{{Field: "email", Rule: "unique", Message: "already exists"}},
+		},
+		{
+			name: "null members", body: `{"name":null,"email":null}`, status: http.StatusBadRequest,
+			details: []responses.ProblemField{
+				{Field: "email", Rule: "required", Message: "email cannot be removed"},
+				{Field: "name", Rule: "required", Message: "name cannot be removed"},
+			},
+		},
+		{
+			name: "invalid email", body: `{"email":"jane"}`, status: http.StatusBadRequest,
+			details: []responses.ProblemField{{Field: "email", Rule: "email", Message: "email must be a valid email address"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &emailEvents{}
+			svc := newDBService(t, events)
+			ctx := context.Background()
+			if _, err := svc.Create(ctx, appuser.CreateUserInput{Email: "taken@example.com", Name: "Taken"}); err != nil {
+				t.Fatal(err)
+			}
+			u, err := svc.Create(ctx, appuser.CreateUserInput{Email: "jane@example.com", Name: "Jane Doe"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := chi.NewRouter()
+			r.Patch("/users/{id}", NewHandler(svc, Options{}, logging.NewNop()).Patch)
+			req := httptest.NewRequest(http.MethodPatch, "/users/"+strconv.FormatInt(u.Id, 10), strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", request.MergePatchContentType)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status == http.StatusOK {
+				var got appuser.UserDto
+				if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				if got.Email != tt.want.Email || got.Name != tt.want.Name {
+					t.Errorf("got %s <%s>, want %s <%s>", got.Name, got.Email, tt.want.Name, tt.want.Email)
+				}
+			} else {
+				var p responses.Problem
+				if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+					t.Fatalf("decode problem: %v", err)
+				}
+				if !reflect.DeepEqual(p.Details, tt.details) {
+					t.Errorf("details = %+v, want %+v", p.Details, tt.details)
+				}
+			}
+
+			var want []string
+			if tt.changes {
+				want = []string{fmt.Sprintf("%d:jane@example.com->%s", u.Id, tt.want.Email)}
+			}
+			if !reflect.DeepEqual(events.changes, want) {
+				t.Errorf("UserEmailChanged = %v, want %v", events.changes, want)
+			}
+		})
+	}
+}
+
+func TestHandler_DeletedUsersNeedExposeDeleted(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{name: "list", method: http.MethodGet, path: "/users?include_deleted=true"},
+		{name: "export", method: http.MethodGet, path: "/users/export?include_deleted=true"},
+		{name: "restore", method: http.MethodPost, path: "/users/%d/restore"},
+	}
+
+	for _, tt := range tests {
+		for _, expose := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s exposed=%v", tt.name, expose), func(t *testing.T) {
+				svc := newDBService(t, appuser.NoopEvents{})
+				ctx := context.Background()
+				u, err := svc.Create(ctx, appuser.CreateUserInput{Email: "jane@example.com", Name: "Jane Doe"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := svc.Delete(ctx, appuser.DeleteUserInput{ID: u.Id}); err != nil {
+					t.Fatal(err)
+				}
+
+				h := NewHandler(svc, Options{ExposeDeleted: expose}, logging.NewNop())
+				r := chi.NewRouter()
+				r.Get("/users", h.List)
+				r.Get("/users/export", h.Export)
+				r.Post("/users/{id}/restore", h.Restore)
+				path := tt.path
+				if strings.Contains(path, "%d") {
+					path = fmt.Sprintf(path, u.Id)
+				}
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, httptest.NewRequest(tt.method, path, nil))
+
+				want := http.StatusForbidden
+				if expose {
+					want = http.StatusOK
+				}
+				if rec.Code != want {
+					t.Fatalf("status = %d, want %d; body: %s", rec.Code, want, rec.Body)
+				}
+				if !expose && !strings.Contains(rec.Body.String(), responses.CodeForbidden) {
+					t.Errorf("body = %s, want code %q", rec.Body, responses.CodeForbidden)
+				}
+				if expose && !strings.Contains(rec.Body.String(), "jane@example.com") {
+					t.Errorf("body = %s, want the deleted user", rec.Body)
+				}
+			})
+		}
+	}
+}
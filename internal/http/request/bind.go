@@ -0,0 +1,119 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	domcommon "kabsa/internal/domain/common"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// MaxBodyBytes caps JSON request bodies.
+const MaxBodyBytes int64 = 1 << 20 // 1 MiB
+
+const JSONContentType = "application/json"
+
+// IsJSON reports whether r declares a JSON body. Handlers check it before
+// Bind and answer 415 otherwise, so form posts and the like fail loudly.
+func IsJSON(r *http.Request) bool {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mt == JSONContentType
+}
+
+// Bind decodes the JSON body of r into dst and validates it using its
+// `validate:"..."` tags. Unknown fields, trailing data and oversized bodies
+// are rejected. All returned errors are domcommon.ValidationError, so
+// handlers can hand them straight to responses.WriteError.
+func Bind[T any](w http.ResponseWriter, r *http.Request, dst *T) error {
+	if err := Decode(w, r, dst); err != nil {
+		return err
+	}
+	return Validate(dst)
+}
+
+// Decode is the decoding half of Bind, for callers that need to inspect the
+// payload before validating it.
+func Decode[T any](w http.ResponseWriter, r *http.Request, dst *T) error {
+	return decodeJSON(http.MaxBytesReader(w, r.Body, MaxBodyBytes), dst)
+}
+
+// Unmarshal decodes one JSON value, such as an NDJSON line, with the same
+// strictness and error reporting as Bind, but without validating it.
+func Unmarshal(data []byte, dst any) error {
+	return decodeJSON(bytes.NewReader(data), dst)
+}
+
+func decodeJSON(body io.Reader, dst any) error {
+	dec := json.NewDecoder(body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+	// Anything after the first JSON value is a client bug, not something to ignore.
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return decodeError(err)
+		}
+		return domcommon.NewValidation("request body must contain a single JSON object")
+	}
+	return nil
+}
+
+func decodeError(err error) error {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		tooLarge  *http.MaxBytesError
+	)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return domcommon.NewValidation("request body is required")
+
+	case errors.As(err, &syntaxErr):
+		return domcommon.NewValidation(fmt.Sprintf("request body is not valid JSON (at offset %d)", syntaxErr.Offset))
+
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return domcommon.NewValidation("request body is not valid JSON (unexpected end of input)")
+
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			return domcommon.NewValidation(fmt.Sprintf("request body must be a JSON %s", typeErr.Type.Kind()))
+		}
+		return domcommon.NewFieldValidation(field, "type", fmt.Sprintf("must be a %s", jsonKind(typeErr.Type.Kind().String())))
+
+	case errors.As(err, &tooLarge):
+		return domcommon.NewValidation(fmt.Sprintf("request body must not exceed %d bytes", tooLarge.Limit))
+
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no typed error for this one.
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return domcommon.NewFieldValidation(field, "unknown", "is not a recognised field")
+
+	default:
+		return domcommon.NewValidation("request body is not valid JSON")
+	}
+}
+
+// jsonKind names Go kinds the way a JSON client would think of them.
+func jsonKind(kind string) string {
+	switch {
+	case strings.HasPrefix(kind, "int"), strings.HasPrefix(kind, "uint"), strings.HasPrefix(kind, "float"):
+		return "number"
+	case kind == "bool":
+		return "boolean"
+	case kind == "slice", kind == "array":
+		return "array"
+	case kind == "map", kind == "struct":
+		return "object"
+	default:
+		return kind
+	}
+}
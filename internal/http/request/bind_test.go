@@ -0,0 +1,144 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	domcommon "kabsa/internal/domain/common"
+)
+
+type bindTarget struct {
+	Email string `json:"email" validate:"required,email"`
+	Name  string `json:"name" validate:"required,notblank,max=10"`
+	Age   int    `json:"age,omitempty" validate:"omitempty,min=0"`
+}
+
+func bind(body string) error {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	var dst bindTarget
+	return Bind(httptest.NewRecorder(), r, &dst)
+}
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		message string // expected ValidationError.Message; empty when fields are checked
+		fields  []domcommon.FieldError
+	}{
+		{name: "valid", body: `{"email":"jane@example.com","name":"Jane"}`},
+		{name: "empty body", body: ``, message: "request body is required"},
+		{name: "not json", body: `email=jane`, message: "request body is not valid JSON (at offset 1)"},
+		{name: "truncated", body: `{"email":"jane@example.com"`, message: "request body is not valid JSON (unexpected end of input)"},
+		{name: "not an object", body: `["jane"]`, message: "request body must be a JSON struct"},
+		{
+			name: "unknown field", body: `{"email":"jane@example.com","name":"Jane","admin":true}`,
+			fields: []domcommon.FieldError{{Field: "admin", Rule: "unknown", Message: "is not a recognised field"}},
+		},
+		{
+			name: "wrong type", body: `{"email":"jane@example.com","name":"Jane","age":"old"}`,
+			fields: []domcommon.FieldError{{Field: "age", Rule: "type", Message: "must be a number"}},
+		},
+		{
+			name: "trailing object", body: `{"email":"jane@example.com","name":"Jane"}{"name":"Joe"}`,
+			message: "request body must contain a single JSON object",
+		},
+		{
+			name: "trailing garbage", body: `{"email":"jane@example.com","name":"Jane"} x`,
+			message: "request body must contain a single JSON object",
+		},
+		{name: "trailing whitespace", body: "{\"email\":\"jane@example.com\",\"name\":\"Jane\"}\n\t "},
+		{
+			name: "validation rules", body: `{"email":"jane","name":"   ","age":-1}`,
+			fields: []domcommon.FieldError{
+				{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+				{Field: "name", Rule: "notblank", Message: "name must not be blank"},
+				{Field: "age", Rule: "min", Message: "age must be 0 or greater"},
+			},
+		},
+		{
+			name: "missing required", body: `{}`,
+			fields: []domcommon.FieldError{
+				{Field: "email", Rule: "required", Message: "email is a required field"},
+				{Field: "name", Rule: "required", Message: "name is a required field"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bind(tt.body)
+
+			if tt.message == "" && tt.fields == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var verr domcommon.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("err = %v, want a validation error", err)
+			}
+			if tt.message != "" && verr.Message != tt.message {
+				t.Errorf("message = %q, want %q", verr.Message, tt.message)
+			}
+			if !reflect.DeepEqual(verr.Fields, tt.fields) {
+				t.Errorf("fields = %+v, want %+v", verr.Fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestBind_BodySizeLimit(t *testing.T) {
+	pad := strings.Repeat(" ", int(MaxBodyBytes))
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "oversized value", body: `{"email":"jane@example.com","name":"` + strings.Repeat("a", int(MaxBodyBytes)) + `"}`},
+		{name: "oversized trailer", body: `{"email":"jane@example.com","name":"Jane"}` + pad},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bind(tt.body)
+
+			var verr domcommon.ValidationError
+			if !errors.As(err, &verr) || !strings.Contains(verr.Message, "must not exceed") {
+				t.Errorf("err = %v, want the body size error", err)
+			}
+		})
+	}
+}
+
+func TestIsJSON(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{contentType: "application/json", want: true},
+		{contentType: "application/json; charset=utf-8", want: true},
+		{contentType: "Application/JSON", want: true},
+		{contentType: "", want: false},
+		{contentType: "text/plain", want: false},
+		{contentType: "application/x-www-form-urlencoded", want: false},
+		{contentType: MergePatchContentType, want: false},
+		{contentType: "application/json; charset", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.Header.Set("Content-Type", tt.contentType)
+
+			if got := IsJSON(r); got != tt.want {
+				t.Errorf("IsJSON = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
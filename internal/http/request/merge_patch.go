@@ -0,0 +1,61 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	domcommon "kabsa/internal/domain/common"
+	"mime"
+	"net/http"
+	"sort"
+)
+
+const MergePatchContentType = "application/merge-patch+json"
+
+// IsMergePatch reports whether r carries a JSON merge patch. Plain
+// application/json is accepted too, since many clients can't set a custom type.
+func IsMergePatch(r *http.Request) bool {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mt == MergePatchContentType || mt == JSONContentType
+}
+
+// BindMergePatch decodes an RFC 7396 JSON merge patch into dst and validates
+// it. dst's fields should be pointers so members absent from the patch stay nil.
+//
+// A member set to null means "remove" in merge-patch, which dst can't tell
+// apart from "absent"; those member names are returned in nulls (sorted) for
+// the caller to accept or reject.
+func BindMergePatch[T any](w http.ResponseWriter, r *http.Request, dst *T) (nulls []string, err error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, decodeError(err)
+		}
+		return nil, domcommon.NewValidation("failed to read request body")
+	}
+
+	// A merge patch that isn't an object would replace the whole resource.
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		return nil, domcommon.NewValidation("merge patch must be a JSON object")
+	}
+	for name, raw := range members {
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			nulls = append(nulls, name)
+		}
+	}
+	sort.Strings(nulls)
+
+	if err := decodeJSON(bytes.NewReader(body), dst); err != nil {
+		return nil, err
+	}
+	if err := Validate(dst); err != nil {
+		return nil, err
+	}
+	return nulls, nil
+}
@@ -0,0 +1,90 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	domcommon "kabsa/internal/domain/common"
+)
+
+type patchTarget struct {
+	Email *string `json:"email,omitempty" validate:"omitempty,email"`
+	Name  *string `json:"name,omitempty" validate:"omitempty,notblank"`
+}
+
+func TestBindMergePatch(t *testing.T) {
+	name := "Jane"
+
+	tests := []struct {
+		name    string
+		body    string
+		want    patchTarget
+		nulls   []string
+		invalid bool
+	}{
+		{name: "one member", body: `{"name":"Jane"}`, want: patchTarget{Name: &name}},
+		{name: "empty patch", body: `{}`},
+		{name: "nulls sorted", body: `{"name":null,"email": null }`, nulls: []string{"email", "name"}},
+		{name: "null beside value", body: `{"name":"Jane","email":null}`, want: patchTarget{Name: &name}, nulls: []string{"email"}},
+		{name: "null patch", body: `null`, invalid: true},
+		{name: "array patch", body: `["name"]`, invalid: true},
+		{name: "string patch", body: `"Jane"`, invalid: true},
+		{name: "unknown member", body: `{"admin":true}`, invalid: true},
+		{name: "invalid value", body: `{"email":"jane"}`, invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(tt.body))
+			var got patchTarget
+
+			nulls, err := BindMergePatch(httptest.NewRecorder(), r, &got)
+
+			if tt.invalid {
+				var verr domcommon.ValidationError
+				if !errors.As(err, &verr) {
+					t.Fatalf("err = %v, want a validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(nulls, tt.nulls) {
+				t.Errorf("nulls = %v, want %v", nulls, tt.nulls)
+			}
+		})
+	}
+}
+
+func TestIsMergePatch(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{contentType: MergePatchContentType, want: true},
+		{contentType: "application/merge-patch+json; charset=utf-8", want: true},
+		{contentType: "application/json", want: true},
+		{contentType: "application/json-patch+json", want: false},
+		{contentType: "text/plain", want: false},
+		{contentType: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPatch, "/", nil)
+			r.Header.Set("Content-Type", tt.contentType)
+
+			if got := IsMergePatch(r); got != tt.want {
+				t.Errorf("IsMergePatch = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
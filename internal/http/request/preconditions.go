@@ -0,0 +1,71 @@
+package request
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ETags is a parsed If-Match or If-None-Match header.
+type ETags struct {
+	Any  bool     // the header was "*"
+	Tags []string // entity tags as sent, quotes and W/ prefix included
+}
+
+// IfMatch parses the If-Match header; ok is false when it is absent.
+func IfMatch(r *http.Request) (tags ETags, ok bool) {
+	return parseETags(r.Header.Values("If-Match"))
+}
+
+// IfNoneMatch parses the If-None-Match header; ok is false when it is absent.
+func IfNoneMatch(r *http.Request) (tags ETags, ok bool) {
+	return parseETags(r.Header.Values("If-None-Match"))
+}
+
+func parseETags(values []string) (ETags, bool) {
+	var tags ETags
+	for _, v := range values {
+		for _, t := range strings.Split(v, ",") {
+			t = strings.TrimSpace(t)
+			switch t {
+			case "":
+			case "*":
+				tags.Any = true
+			default:
+				tags.Tags = append(tags.Tags, t)
+			}
+		}
+	}
+	return tags, tags.Any || len(tags.Tags) > 0
+}
+
+// MatchStrong uses the strong comparison required for If-Match:
+// weak tags never match.
+func (t ETags) MatchStrong(etag string) bool {
+	if t.Any {
+		return true
+	}
+	if strings.HasPrefix(etag, "W/") {
+		return false
+	}
+	for _, tag := range t.Tags {
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchWeak uses the weak comparison required for If-None-Match:
+// W/"x" and "x" are equivalent.
+func (t ETags) MatchWeak(etag string) bool {
+	if t.Any {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range t.Tags {
+		if strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,8 @@
+package request
+
+import "kabsa/internal/validation"
+
+// Validate runs struct validation on v; see validation.Struct.
+func Validate(v any) error {
+	return validation.Struct(v)
+}
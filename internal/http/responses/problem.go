@@ -0,0 +1,158 @@
+package responses
+
+import (
+	"encoding/json"
+	"errors"
+	domcommon "kabsa/internal/domain/common"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Stable machine-readable error codes. Clients switch on these, so never
+// rename one; add a new code instead.
+const (
+	CodeBadRequest   = "bad_request"
+	CodeValidation   = "validation_failed"
+	CodeNotFound     = "not_found"
+	CodeMethod       = "method_not_allowed"
+	CodeMediaType    = "unsupported_media_type"
+	CodeConflict     = "conflict"
+	CodeUnauthorized = "unauthorized"
+	CodeForbidden    = "forbidden"
+	CodeRateLimited  = "rate_limited"
+	CodeStale        = "precondition_failed"
+	CodeNoPrecond    = "precondition_required"
+	CodeUnavailable  = "unavailable"
+	CodeInternal     = "internal_error"
+)
+
+// Problem is an RFC 7807 problem details body, extended with a stable code,
+// per-field details and the trace ID of the request.
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Code     string         `json:"code"`
+	Details  []ProblemField `json:"details,omitempty"`
+	TraceID  string         `json:"traceId,omitempty"`
+
+	retryAfter time.Duration
+}
+
+// ProblemField is one entry of Problem.Details.
+type ProblemField struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+// WriteError maps err onto the domain error taxonomy and writes it as
+// problem+json. Anything unrecognised becomes a 500 without leaking err.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	WriteProblem(w, r, ProblemFor(err))
+}
+
+// StatusFor returns the HTTP status WriteError would use for err.
+func StatusFor(err error) int {
+	return ProblemFor(err).Status
+}
+
+// ProblemFor builds the Problem for err without writing it.
+func ProblemFor(err error) Problem {
+	var (
+		notFound     domcommon.NotFoundError
+		conflict     domcommon.ConflictError
+		validation   domcommon.ValidationError
+		unauthorized domcommon.UnauthorizedError
+		forbidden    domcommon.ForbiddenError
+		rateLimited  domcommon.RateLimitedError
+		unavailable  domcommon.UnavailableError
+		stale        domcommon.PreconditionFailedError
+		noPrecond    domcommon.PreconditionRequiredError
+	)
+
+	switch {
+	case errors.As(err, &notFound):
+		return Problem{Status: http.StatusNotFound, Code: CodeNotFound, Detail: notFound.Error()}
+
+	case errors.As(err, &conflict):
+		p := Problem{Status: http.StatusConflict, Code: CodeConflict, Detail: conflict.Error()}
+		if conflict.Field != "" {
+			p.Details = []ProblemField{{Field: conflict.Field, Rule: "unique", Message: "already exists"}}
+		}
+		return p
+
+	case errors.As(err, &validation):
+		p := Problem{Status: http.StatusBadRequest, Code: CodeValidation, Detail: validation.Error()}
+		for _, f := range validation.Fields {
+			p.Details = append(p.Details, ProblemField{Field: f.Field, Rule: f.Rule, Message: f.Message})
+		}
+		return p
+
+	case errors.As(err, &stale):
+		return Problem{Status: http.StatusPreconditionFailed, Code: CodeStale, Detail: stale.Error()}
+
+	case errors.As(err, &noPrecond):
+		return Problem{Status: http.StatusPreconditionRequired, Code: CodeNoPrecond, Detail: noPrecond.Error()}
+
+	case errors.As(err, &unauthorized):
+		return Problem{Status: http.StatusUnauthorized, Code: CodeUnauthorized, Detail: unauthorized.Error()}
+
+	case errors.As(err, &forbidden):
+		return Problem{Status: http.StatusForbidden, Code: CodeForbidden, Detail: forbidden.Error()}
+
+	case errors.As(err, &rateLimited):
+		return Problem{
+			Status:     http.StatusTooManyRequests,
+			Code:       CodeRateLimited,
+			Detail:     rateLimited.Error(),
+			retryAfter: rateLimited.RetryAfter,
+		}
+
+	case errors.As(err, &unavailable):
+		// Don't echo the wrapped cause, it may contain internal addresses.
+		return Problem{
+			Status:     http.StatusServiceUnavailable,
+			Code:       CodeUnavailable,
+			Detail:     unavailable.Dependency + " is temporarily unavailable",
+			retryAfter: unavailable.RetryAfter,
+		}
+
+	default:
+		return Problem{Status: http.StatusInternalServerError, Code: CodeInternal, Detail: "internal server error"}
+	}
+}
+
+// WriteProblem fills in the defaults (type, title, instance, trace ID) and
+// writes p with the problem+json content type.
+func WriteProblem(w http.ResponseWriter, r *http.Request, p Problem) {
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+	if p.Instance == "" && r != nil {
+		p.Instance = r.URL.Path
+	}
+	if r != nil {
+		if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+			p.TraceID = sc.TraceID().String()
+		}
+	}
+	if p.retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(p.retryAfter.Seconds()))))
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
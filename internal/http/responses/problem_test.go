@@ -0,0 +1,110 @@
+package responses
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	domcommon "kabsa/internal/domain/common"
+)
+
+func TestProblemFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		details []ProblemField
+	}{
+		{name: "not found", err: domcommon.NewNotFound("user"), status: http.StatusNotFound, code: CodeNotFound},
+		{
+			name: "conflict on field", err: domcommon.NewConflict("user", "email", ""),
+			status: http.StatusConflict, code: CodeConflict,
+			details: []ProblemField{{Field: "email", Rule: "unique", Message: "already exists"}},
+		},
+		{name: "conflict", err: domcommon.NewConflict("user", "", ""), status: http.StatusConflict, code: CodeConflict},
+		{
+			name: "validation", err: domcommon.NewValidation("bad input",
+				domcommon.FieldError{Field: "email", Rule: "email", Message: "must be a valid email"},
+				domcommon.FieldError{Field: "name", Rule: "required", Message: "is required"}),
+			status: http.StatusBadRequest, code: CodeValidation,
+			details: []ProblemField{
+				{Field: "email", Rule: "email", Message: "must be a valid email"},
+				{Field: "name", Rule: "required", Message: "is required"},
+			},
+		},
+		{name: "precondition failed", err: domcommon.NewPreconditionFailed("user"), status: http.StatusPreconditionFailed, code: CodeStale},
+		{name: "precondition required", err: domcommon.NewPreconditionRequired(""), status: http.StatusPreconditionRequired, code: CodeNoPrecond},
+		{name: "unauthorized", err: domcommon.NewUnauthorized(""), status: http.StatusUnauthorized, code: CodeUnauthorized},
+		{name: "forbidden", err: domcommon.NewForbidden(""), status: http.StatusForbidden, code: CodeForbidden},
+		{name: "rate limited", err: domcommon.NewRateLimited(time.Second), status: http.StatusTooManyRequests, code: CodeRateLimited},
+		{name: "unavailable", err: domcommon.NewUnavailable("redis", errors.New("dial 10.0.0.1")), status: http.StatusServiceUnavailable, code: CodeUnavailable},
+		{name: "wrapped", err: fmt.Errorf("get user: %w", domcommon.NewNotFound("user")), status: http.StatusNotFound, code: CodeNotFound},
+		{name: "unknown", err: errors.New("pq: connection refused"), status: http.StatusInternalServerError, code: CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ProblemFor(tt.err)
+
+			if p.Status != tt.status || p.Code != tt.code {
+				t.Errorf("got %d %q, want %d %q", p.Status, p.Code, tt.status, tt.code)
+			}
+			if !reflect.DeepEqual(p.Details, tt.details) {
+				t.Errorf("details = %+v, want %+v", p.Details, tt.details)
+			}
+			if StatusFor(tt.err) != tt.status {
+				t.Errorf("StatusFor = %d, want %d", StatusFor(tt.err), tt.status)
+			}
+		})
+	}
+}
+
+func TestProblemFor_HidesInternalCauses(t *testing.T) {
+	for _, err := range []error{
+		errors.New("pq: password authentication failed"),
+		domcommon.NewUnavailable("postgres", errors.New("dial tcp 10.0.0.1:5432")),
+	} {
+		if p := ProblemFor(err); p.Detail == err.Error() {
+			t.Errorf("detail leaks %q", err)
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	rec := httptest.NewRecorder()
+
+	WriteError(rec, r, domcommon.NewRateLimited(1500*time.Millisecond))
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, ProblemContentType)
+	}
+	if ra := rec.Header().Get("Retry-After"); ra != "2" {
+		t.Errorf("Retry-After = %q, want %q", ra, "2")
+	}
+
+	var p Problem
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(http.StatusTooManyRequests),
+		Status:   http.StatusTooManyRequests,
+		Detail:   "rate limit exceeded",
+		Instance: "/users/42",
+		Code:     CodeRateLimited,
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("got %+v, want %+v", p, want)
+	}
+}
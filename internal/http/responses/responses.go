@@ -5,10 +5,6 @@ import (
 	"net/http"
 )
 
-type ErrorResponse struct {
-	Error string `json:"error"`
-}
-
 func WriteJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -18,14 +14,31 @@ func WriteJSON(w http.ResponseWriter, status int, v any) {
 	_ = json.NewEncoder(w).Encode(v)
 }
 
-func WriteError(w http.ResponseWriter, status int, msg string) {
-	WriteJSON(w, status, ErrorResponse{Error: msg})
+func WriteNotFound(w http.ResponseWriter, r *http.Request) {
+	WriteProblem(w, r, Problem{
+		Status: http.StatusNotFound,
+		Code:   CodeNotFound,
+		Detail: "resource not found",
+	})
 }
 
-func WriteNotFound(w http.ResponseWriter, r *http.Request) {
-	WriteError(w, http.StatusNotFound, "resource not found")
+func WriteMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	WriteProblem(w, r, Problem{
+		Status: http.StatusMethodNotAllowed,
+		Code:   CodeMethod,
+		Detail: r.Method + " is not supported on this resource",
+	})
 }
 
-func WriteBadRequest(w http.ResponseWriter, msg string) {
-	WriteError(w, http.StatusBadRequest, msg)
+// WriteUnsupportedMediaType answers 415 naming the accepted types; PATCH
+// advertises them in Accept-Patch too (RFC 5789).
+func WriteUnsupportedMediaType(w http.ResponseWriter, r *http.Request, accepted string) {
+	if r.Method == http.MethodPatch {
+		w.Header().Set("Accept-Patch", accepted)
+	}
+	WriteProblem(w, r, Problem{
+		Status: http.StatusUnsupportedMediaType,
+		Code:   CodeMediaType,
+		Detail: "Content-Type must be " + accepted,
+	})
 }
@@ -1,26 +1,32 @@
 ﻿package router
 
 import (
+	"fmt"
 	"net/http"
+	"net/netip"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
+	"kabsa/internal/domain/audit"
 	"kabsa/internal/logging"
 )
 
-func useBaseMiddlewares(r chi.Router, logger logging.Logger, serviceName string) {
+func useBaseMiddlewares(r chi.Router, logger logging.Logger, serviceName, actorHeader string, trustedProxies []netip.Prefix) {
 	// Request ID / Real IP / Recover
 	r.Use(middleware.RequestID)
+	// Before RealIP, which replaces the peer address with client-supplied
+	// headers.
+	r.Use(auditContext(actorHeader, trustedProxies))
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Recoverer)
 
 	// Logging middleware (your own)
 	r.Use(requestLoggingMiddleware(logger))
 
-	// Optional: timeout middleware
-	r.Use(middleware.Timeout(60 * time.Second))
+	// Timeouts are per route; see NewRouter.
 }
 
 // Example logging middleware – keep or adjust as you like.
@@ -42,3 +48,63 @@ func requestLoggingMiddleware(logger logging.Logger) func(next http.Handler) htt
 		})
 	}
 }
+
+// auditContext puts the actor from actorHeader and the request ID into the
+// request context, where the audit log picks them up. The API does no
+// authentication itself: the header is only taken at face value from a
+// trusted proxy, and recorded as unverified from anyone else.
+func auditContext(actorHeader string, trustedProxies []netip.Prefix) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := audit.WithRequestID(r.Context(), middleware.GetReqID(r.Context()))
+			if actor := r.Header.Get(actorHeader); actor != "" {
+				if !fromTrustedProxy(r.RemoteAddr, trustedProxies) {
+					actor = audit.UnverifiedActorPrefix + actor
+				}
+				ctx = audit.WithActor(ctx, actor)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ParseTrustedProxies parses IPs and CIDRs as accepted by
+// HTTP_TRUSTED_PROXIES. A bare IP trusts that single address.
+func ParseTrustedProxies(list []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(list))
+	for _, s := range list {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "/") {
+			addr, err := netip.ParseAddr(s)
+			if err != nil {
+				return nil, fmt.Errorf("trusted proxy %q: %w", s, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q: %w", s, err)
+		}
+		prefixes = append(prefixes, p.Masked())
+	}
+	return prefixes, nil
+}
+
+// fromTrustedProxy reports whether the connection's peer is a trusted proxy.
+func fromTrustedProxy(remoteAddr string, trusted []netip.Prefix) bool {
+	ap, err := netip.ParseAddrPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	addr := ap.Addr().Unmap()
+	for _, p := range trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
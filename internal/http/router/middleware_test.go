@@ -0,0 +1,54 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"kabsa/internal/domain/audit"
+)
+
+func TestAuditContext_TrustsActorOnlyFromProxies(t *testing.T) {
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		header     string
+		want       string
+	}{
+		{"trusted CIDR", "10.1.2.3:5555", "alice", "alice"},
+		{"trusted single IP", "192.0.2.7:5555", "alice", "alice"},
+		{"IPv4-mapped trusted", "[::ffff:10.1.2.3]:5555", "alice", "alice"},
+		{"untrusted peer", "203.0.113.9:5555", "alice", audit.UnverifiedActorPrefix + "alice"},
+		{"no header", "10.1.2.3:5555", "", audit.UnknownActor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			h := auditContext("X-Actor", trusted)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = audit.ActorFrom(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.header != "" {
+				req.Header.Set("X-Actor", tt.header)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("actor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxies_RejectsGarbage(t *testing.T) {
+	if _, err := ParseTrustedProxies([]string{"not-an-ip"}); err == nil {
+		t.Error("want an error")
+	}
+}
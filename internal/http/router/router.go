@@ -6,33 +6,65 @@ import (
 	"kabsa/internal/http/responses"
 	"kabsa/internal/logging"
 	"net/http"
+	"net/netip"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
+// Options tunes router behaviour that differs between deployments.
+type Options struct {
+	// ActorHeader names the request header carrying the audit actor.
+	ActorHeader string
+	// TrustedProxies may set ActorHeader; see ParseTrustedProxies.
+	TrustedProxies []netip.Prefix
+	// RequestTimeout bounds ordinary requests.
+	RequestTimeout time.Duration
+	// StreamTimeout bounds endpoints that stream large bodies (export,
+	// import), which would not fit in RequestTimeout.
+	StreamTimeout time.Duration
+}
+
 func NewRouter(
 	logger logging.Logger,
 	serviceName string,
+	opts Options,
 	healthHandler *health.Handler,
 	userHandler *userhandler.Handler,
 ) chi.Router {
 	r := chi.NewRouter()
 
-	useBaseMiddlewares(r, logger, serviceName)
+	useBaseMiddlewares(r, logger, serviceName, opts.ActorHeader, opts.TrustedProxies)
+
+	// A timeout can only shorten the context deadline, never extend it, so
+	// each route gets exactly one of these instead of a global default.
+	timeout := middleware.Timeout(opts.RequestTimeout)
+	streamTimeout := middleware.Timeout(opts.StreamTimeout)
 
-	r.Get("/swagger/*", httpSwagger.Handler(httpSwagger.URL("/swagger/doc.json")))
+	r.With(timeout).Get("/swagger/*", httpSwagger.Handler(httpSwagger.URL("/swagger/doc.json")))
 	r.Route("/api/v1", func(r chi.Router) {
 		// Health
-		r.Get("/health", healthHandler.Check)
+		r.With(timeout).Get("/health", healthHandler.Check)
 
 		// User module
+		r.With(timeout).Post("/users:batch", userHandler.Batch)
+		r.With(streamTimeout).Post("/users:import", userHandler.Import)
 		r.Route("/users", func(r chi.Router) {
-			r.Get("/", userHandler.List)
-			r.Post("/", userHandler.Create)
-			r.Get("/{id}", userHandler.GetByID)
-			r.Put("/{id}", userHandler.Update)
-			r.Delete("/{id}", userHandler.Delete)
+			r.With(streamTimeout).Get("/export", userHandler.Export)
+
+			r.Group(func(r chi.Router) {
+				r.Use(timeout)
+				r.Get("/", userHandler.List)
+				r.Post("/", userHandler.Create)
+				r.Get("/{id}", userHandler.GetByID)
+				r.Put("/{id}", userHandler.Update)
+				r.Patch("/{id}", userHandler.Patch)
+				r.Delete("/{id}", userHandler.Delete)
+				r.Post("/{id}/restore", userHandler.Restore)
+				r.Get("/{id}/history", userHandler.History)
+			})
 		})
 	})
 
@@ -40,6 +72,9 @@ func NewRouter(
 	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
 		responses.WriteNotFound(w, r)
 	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		responses.WriteMethodNotAllowed(w, r)
+	})
 
 	return r
 }
@@ -0,0 +1,115 @@
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"kabsa/ent/inboxmessage"
+	"kabsa/internal/db"
+	"kabsa/internal/kafka"
+	"kabsa/internal/logging"
+	"time"
+)
+
+// Inbox records the Envelope.MessageID of every message a consumer group
+// has processed, so redeliveries are skipped. It is the consumer side of
+// the outbox: the relay publishes at least once, the inbox makes handling
+// effectively once.
+type Inbox struct {
+	client    *db.Client
+	group     string
+	retention time.Duration
+	metrics   *metrics
+	logger    logging.Logger
+}
+
+var _ kafka.Deduplicator = (*Inbox)(nil)
+
+// New returns the inbox of the given consumer group. Records are kept for
+// retention, which must outlast any redelivery: at least the retention of
+// the topics consumed.
+func New(client *db.Client, group string, retention time.Duration, logger logging.Logger) *Inbox {
+	logger = logger.With("component", "inbox", "consumerGroup", group)
+	return &Inbox{
+		client:    client,
+		group:     group,
+		retention: retention,
+		metrics:   newMetrics(group, logger),
+		logger:    logger,
+	}
+}
+
+// insertSQL records a message unless the group already has it. ON CONFLICT
+// rather than a unique-violation check: in Postgres a failed statement
+// aborts the whole transaction.
+const insertSQL = `INSERT INTO inbox (consumer_group, message_id, processed_at) VALUES ($1, $2, $3)
+ON CONFLICT (consumer_group, message_id) DO NOTHING`
+
+// Once runs handle in a transaction that also records messageID, unless the
+// group has processed messageID before. If handle fails, nothing is
+// recorded and the message can be handled again. A concurrent delivery of
+// the same message waits on the first one's row lock and then skips.
+func (i *Inbox) Once(ctx context.Context, messageID string, handle func(ctx context.Context) error) (bool, error) {
+	if messageID == "" {
+		// Nothing to dedup on; treat it as new.
+		i.logger.Debug("message without id, handling without dedup")
+		return false, handle(ctx)
+	}
+
+	var duplicate bool
+	err := i.client.WithTx(ctx, func(ctx context.Context) error {
+		res, err := i.client.EntFor(ctx).ExecContext(ctx, insertSQL, i.group, messageID, time.Now())
+		if err != nil {
+			return fmt.Errorf("record inbox message: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("record inbox message: %w", err)
+		}
+		duplicate = n == 0
+		if duplicate {
+			return nil
+		}
+		return handle(ctx)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	i.metrics.handled(ctx, duplicate)
+	return duplicate, nil
+}
+
+// Run prunes old records every hour until ctx is cancelled.
+func (i *Inbox) Run(ctx context.Context) error {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if n, err := i.Prune(ctx); err != nil {
+			i.logger.Error("inbox prune failed", "error", err)
+		} else if n > 0 {
+			i.logger.Info("pruned inbox records", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune deletes the group's records older than the retention.
+func (i *Inbox) Prune(ctx context.Context) (int, error) {
+	n, err := i.client.Ent().InboxMessage.
+		Delete().
+		Where(
+			inboxmessage.ConsumerGroup(i.group),
+			inboxmessage.ProcessedAtLT(time.Now().Add(-i.retention)),
+		).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ent.InboxMessage.Delete: %w", err)
+	}
+	return n, nil
+}
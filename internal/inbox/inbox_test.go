@@ -0,0 +1,98 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"kabsa/internal/db"
+	"kabsa/internal/db/dbtest"
+	"kabsa/internal/logging"
+)
+
+func TestInbox_SkipsRedeliveries(t *testing.T) {
+	client := dbtest.NewClient(t)
+	ctx := context.Background()
+	in := New(client, "group-a", time.Hour, logging.NewNop())
+
+	calls := 0
+	handle := func(ctx context.Context) error {
+		if !db.InTx(ctx) {
+			t.Error("handler should run in the inbox transaction")
+		}
+		calls++
+		return nil
+	}
+
+	for i, wantDup := range []bool{false, true, true} {
+		dup, err := in.Once(ctx, "m1", handle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dup != wantDup {
+			t.Errorf("delivery %d: duplicate = %v, want %v", i+1, dup, wantDup)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+
+	// Another consumer group processes the same message independently.
+	if dup, err := New(client, "group-b", time.Hour, logging.NewNop()).Once(ctx, "m1", handle); err != nil || dup {
+		t.Errorf("group-b: duplicate = %v, err = %v", dup, err)
+	}
+}
+
+func TestInbox_FailedHandlerIsNotRecorded(t *testing.T) {
+	client := dbtest.NewClient(t)
+	ctx := context.Background()
+	in := New(client, "group-a", time.Hour, logging.NewNop())
+
+	boom := errors.New("boom")
+	if _, err := in.Once(ctx, "m1", func(ctx context.Context) error {
+		// A side effect in the same transaction is rolled back with the record.
+		if err := client.EntFor(ctx).User.Create().SetEmail("a@example.com").SetName("A").Exec(ctx); err != nil {
+			t.Fatal(err)
+		}
+		return boom
+	}); !errors.Is(err, boom) {
+		t.Fatalf("got %v, want boom", err)
+	}
+	if n := client.Ent().User.Query().CountX(ctx); n != 0 {
+		t.Errorf("%d users left behind by the failed handler", n)
+	}
+
+	calls := 0
+	dup, err := in.Once(ctx, "m1", func(context.Context) error { calls++; return nil })
+	if err != nil || dup || calls != 1 {
+		t.Errorf("redelivery after failure: duplicate = %v, err = %v, calls = %d", dup, err, calls)
+	}
+}
+
+func TestInbox_Prune(t *testing.T) {
+	client := dbtest.NewClient(t)
+	ctx := context.Background()
+	in := New(client, "group-a", time.Hour, logging.NewNop())
+
+	client.Ent().InboxMessage.Create().
+		SetConsumerGroup("group-a").SetMessageID("old").SetProcessedAt(time.Now().Add(-2 * time.Hour)).
+		ExecX(ctx)
+	client.Ent().InboxMessage.Create().
+		SetConsumerGroup("group-b").SetMessageID("other-group").SetProcessedAt(time.Now().Add(-2 * time.Hour)).
+		ExecX(ctx)
+	if _, err := in.Once(ctx, "new", func(context.Context) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := in.Prune(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("pruned %d records, want 1", n)
+	}
+	if left := client.Ent().InboxMessage.Query().CountX(ctx); left != 2 {
+		t.Errorf("%d records left, want 2", left)
+	}
+}
@@ -0,0 +1,53 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"kabsa/internal/logging"
+)
+
+// meterName scopes the inbox instruments; the provider comes from
+// telemetry.Setup via the global.
+const meterName = "kabsa/internal/inbox"
+
+type metrics struct {
+	processed  metric.Int64Counter
+	duplicates metric.Int64Counter
+	attrs      metric.MeasurementOption
+}
+
+func newMetrics(group string, logger logging.Logger) *metrics {
+	meter := otel.Meter(meterName)
+
+	processed, err1 := meter.Int64Counter("messaging.inbox.processed",
+		metric.WithDescription("Messages handled for the first time by the consumer group"),
+		metric.WithUnit("{message}"))
+	duplicates, err2 := meter.Int64Counter("messaging.inbox.duplicates",
+		metric.WithDescription("Redelivered messages skipped because the consumer group had processed them"),
+		metric.WithUnit("{message}"))
+
+	m := &metrics{
+		processed:  processed,
+		duplicates: duplicates,
+		attrs:      metric.WithAttributes(attribute.String("messaging.consumer.group.name", group)),
+	}
+	if err := errors.Join(err1, err2); err != nil {
+		logger.Error("failed to create inbox metrics, continuing without them", "error", err)
+		m.processed, m.duplicates = noop.Int64Counter{}, noop.Int64Counter{}
+	}
+	return m
+}
+
+func (m *metrics) handled(ctx context.Context, duplicate bool) {
+	if duplicate {
+		m.duplicates.Add(ctx, 1, m.attrs)
+		return
+	}
+	m.processed.Add(ctx, 1, m.attrs)
+}
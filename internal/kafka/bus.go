@@ -3,5 +3,10 @@
 import "context"
 
 type Bus interface {
-	Publish(ctx context.Context, topic string, msgType string, payload any) error
+	// Publish wraps payload in a new Envelope. Messages with the same key
+	// land on the same partition, so consumers see them in publish order.
+	Publish(ctx context.Context, topic string, key string, msgType string, payload any) error
+	// PublishEnvelope publishes an already built envelope as is, keeping its
+	// MessageID; used to re-publish stored messages.
+	PublishEnvelope(ctx context.Context, topic string, key string, env Envelope) error
 }
@@ -4,18 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/IBM/sarama"
+	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill-kafka/v3/pkg/kafka"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/garsue/watermillzap"
-	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"kabsa/internal/config"
 	"kabsa/internal/logging"
-	"time"
 )
 
 type watermillBus struct {
 	publisher message.Publisher
+	tracer    trace.Tracer
 	logger    logging.Logger
 }
 
@@ -30,28 +33,14 @@ func NewBus(cfg config.KafkaConfig, baseLogger logging.Logger) (Bus, func(ctx co
 
 	wmlogger := watermillzap.NewLogger(zapLogger)
 
-	marshaler := kafka.DefaultMarshaler{}
-
-	// Publisher config
-	pubCfg := kafka.PublisherConfig{
-		Brokers:   cfg.Brokers,
-		Marshaler: marshaler,
-		// You can tweak Sarama config here if needed:
-		OverwriteSaramaConfig: func() *sarama.Config {
-			c := kafka.DefaultSaramaSyncPublisherConfig()
-			c.ClientID = cfg.ClientID
-			// TODO: add TLS/SASL if needed
-			return c
-		}(),
-	}
-
-	publisher, err := kafka.NewPublisher(pubCfg, wmlogger)
+	publisher, err := newPublisher(cfg, wmlogger)
 	if err != nil {
-		return nil, nil, fmt.Errorf("create kafka publisher: %w", err)
+		return nil, nil, err
 	}
 
 	bus := &watermillBus{
 		publisher: publisher,
+		tracer:    otel.Tracer(tracerName),
 		logger:    baseLogger.With("component", "kafka_bus"),
 	}
 
@@ -63,25 +52,57 @@ func NewBus(cfg config.KafkaConfig, baseLogger logging.Logger) (Bus, func(ctx co
 	return bus, closeFn, nil
 }
 
-func (b *watermillBus) Publish(ctx context.Context, topic string, msgType string, payload any) error {
-	// 1) Serialize payload
-	payloadBytes, err := json.Marshal(payload)
+// newPublisher builds a sync publisher that partitions by partitionKeyHeader.
+func newPublisher(cfg config.KafkaConfig, wmlogger watermill.LoggerAdapter) (message.Publisher, error) {
+	// Partition by the key given to Publish so per-aggregate order holds.
+	marshaler := kafka.NewWithPartitioningMarshaler(func(topic string, msg *message.Message) (string, error) {
+		return msg.Metadata.Get(partitionKeyHeader), nil
+	})
+
+	saramaCfg, err := saramaConfig(kafka.DefaultSaramaSyncPublisherConfig(), cfg)
 	if err != nil {
-		return fmt.Errorf("marshal payload: %w", err)
+		return nil, err
 	}
 
-	// 2) Wrap into envelope
-	env := Envelope{
-		MessageID:  uuid.NewString(),
-		Type:       msgType,
-		OccurredAt: time.Now().UTC(),
-		Payload:    payloadBytes,
+	// Publisher config
+	pubCfg := kafka.PublisherConfig{
+		Brokers:               cfg.Brokers,
+		Marshaler:             marshaler,
+		OverwriteSaramaConfig: saramaCfg,
 	}
 
-	// TODO: optionally extract correlation ID from context
-	// if cid := correlation.FromContext(ctx); cid != "" {
-	//     env.CorrelationID = cid
-	// }
+	publisher, err := kafka.NewPublisher(pubCfg, wmlogger)
+	if err != nil {
+		return nil, fmt.Errorf("create kafka publisher: %w", err)
+	}
+	return publisher, nil
+}
+
+// partitionKeyHeader carries the Kafka message key through Watermill metadata.
+const partitionKeyHeader = "partitionKey"
+
+func (b *watermillBus) Publish(ctx context.Context, topic string, key string, msgType string, payload any) error {
+	env, err := NewEnvelope(msgType, payload)
+	if err != nil {
+		return err
+	}
+	return b.PublishEnvelope(ctx, topic, key, env)
+}
+
+// PublishEnvelope sends env in a producer span whose trace context, along
+// with the request ID, travels in the message headers. A missing
+// CorrelationID is filled in from ctx.
+func (b *watermillBus) PublishEnvelope(ctx context.Context, topic string, key string, env Envelope) error {
+	ctx, span := b.tracer.Start(ctx, topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		messagingAttrs(topic, env.MessageID),
+		trace.WithAttributes(attribute.String("messaging.operation.type", "send")),
+	)
+	defer span.End()
+
+	if env.CorrelationID == "" {
+		env.CorrelationID = correlationID(ctx)
+	}
 
 	body, err := json.Marshal(env)
 	if err != nil {
@@ -89,18 +110,22 @@ func (b *watermillBus) Publish(ctx context.Context, topic string, msgType string
 	}
 
 	msg := message.NewMessage(env.MessageID, body)
-
-	// Optional: set headers (correlation id, trace id, etc.)
+	msg.Metadata.Set(partitionKeyHeader, key)
+	for k, v := range ContextHeaders(ctx) {
+		msg.Metadata.Set(k, v)
+	}
 	if env.CorrelationID != "" {
-		msg.Metadata.Set("correlationId", env.CorrelationID)
+		msg.Metadata.Set(CorrelationIDHeader, env.CorrelationID)
 	}
 
 	if err := b.publisher.Publish(topic, msg); err != nil {
 		b.logger.Error("failed to publish kafka message",
 			"topic", topic,
-			"type", msgType,
+			"type", env.Type,
 			"error", err,
 		)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return fmt.Errorf("publish: %w", err)
 	}
 
@@ -110,6 +135,10 @@ func (b *watermillBus) Publish(ctx context.Context, topic string, msgType string
 // No-op implementation when Kafka is disabled.
 type noopBus struct{}
 
-func (*noopBus) Publish(ctx context.Context, topic string, msgType string, payload any) error {
+func (*noopBus) Publish(ctx context.Context, topic string, key string, msgType string, payload any) error {
+	return nil
+}
+
+func (*noopBus) PublishEnvelope(ctx context.Context, topic string, key string, env Envelope) error {
 	return nil
 }
@@ -0,0 +1,108 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kabsa/internal/logging"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+// UnknownTypePolicy decides what happens to messages no handler is
+// registered for.
+type UnknownTypePolicy int
+
+const (
+	// SkipUnknown acknowledges them: topics are shared by producers that add
+	// event types before every consumer knows them.
+	SkipUnknown UnknownTypePolicy = iota
+	// RejectUnknown fails them like a handler error.
+	RejectUnknown
+)
+
+// ErrUnknownType is returned for messages rejected under RejectUnknown.
+// Like decode failures it is Permanent: retrying will not help.
+var ErrUnknownType = errors.New("no handler for message type")
+
+// Deduplicator runs handle at most once per message ID. handle's ctx may
+// carry a database transaction that also records the ID, so its side
+// effects and the record commit together. duplicate reports a skipped call.
+type Deduplicator interface {
+	Once(ctx context.Context, messageID string, handle func(ctx context.Context) error) (duplicate bool, err error)
+}
+
+// Dispatcher decodes the Envelope of each message on a topic and hands its
+// payload to the handler registered for the envelope's Type.
+type Dispatcher struct {
+	handlers map[string]func(ctx context.Context, env Envelope) error
+	unknown  UnknownTypePolicy
+	dedup    Deduplicator // nil handles every delivery
+	logger   logging.Logger
+}
+
+func NewDispatcher(unknown UnknownTypePolicy, logger logging.Logger) *Dispatcher {
+	return &Dispatcher{
+		handlers: make(map[string]func(ctx context.Context, env Envelope) error),
+		unknown:  unknown,
+		logger:   logger.With("component", "kafka_dispatcher"),
+	}
+}
+
+// Deduplicate makes d skip messages dedup has already seen. Kafka delivers
+// at least once, so handlers with non-idempotent side effects need it.
+func (d *Dispatcher) Deduplicate(dedup Deduplicator) {
+	d.dedup = dedup
+}
+
+// Register routes envelopes of msgType to fn with the payload decoded as T.
+// Registering a type twice is a wiring bug and panics.
+func Register[T any](d *Dispatcher, msgType string, fn func(ctx context.Context, env Envelope, payload T) error) {
+	if _, ok := d.handlers[msgType]; ok {
+		panic(fmt.Sprintf("kafka: handler for %q registered twice", msgType))
+	}
+	d.handlers[msgType] = func(ctx context.Context, env Envelope) error {
+		var payload T
+		if err := json.Unmarshal(env.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("decode %s payload: %w", msgType, err))
+		}
+		return fn(ctx, env, payload)
+	}
+}
+
+// Handle is a watermill.NoPublishHandlerFunc. The router retries a returned
+// error and then dead-letters the message; Permanent errors skip the retries.
+func (d *Dispatcher) Handle(msg *message.Message) error {
+	var env Envelope
+	if err := json.Unmarshal(msg.Payload, &env); err != nil {
+		return Permanent(fmt.Errorf("decode envelope of message %s: %w", msg.UUID, err))
+	}
+
+	handler, ok := d.handlers[env.Type]
+	if !ok {
+		if d.unknown == RejectUnknown {
+			return Permanent(fmt.Errorf("%w: %q (message %s)", ErrUnknownType, env.Type, env.MessageID))
+		}
+		d.logger.Debug("skipping message of unknown type", "type", env.Type, "message_id", env.MessageID)
+		return nil
+	}
+
+	if d.dedup == nil {
+		if err := handler(msg.Context(), env); err != nil {
+			return fmt.Errorf("handle %s message %s: %w", env.Type, env.MessageID, err)
+		}
+		return nil
+	}
+
+	duplicate, err := d.dedup.Once(msg.Context(), env.MessageID, func(ctx context.Context) error {
+		return handler(ctx, env)
+	})
+	if err != nil {
+		return fmt.Errorf("handle %s message %s: %w", env.Type, env.MessageID, err)
+	}
+	if duplicate {
+		d.logger.Debug("skipping duplicate message", "type", env.Type, "message_id", env.MessageID)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+
+	"kabsa/internal/logging"
+)
+
+func envelopeMessage(t *testing.T, msgType string, payload any) *message.Message {
+	t.Helper()
+	env, err := NewEnvelope(msgType, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return message.NewMessage(env.MessageID, body)
+}
+
+func TestDispatcher_RoutesByTypeWithTypedPayload(t *testing.T) {
+	d := NewDispatcher(SkipUnknown, logging.NewNop())
+
+	var got UserDeletedPayload
+	var gotType string
+	Register(d, UserDeletedType, func(_ context.Context, env Envelope, p UserDeletedPayload) error {
+		gotType, got = env.Type, p
+		return nil
+	})
+	Register(d, UserRestoredType, func(context.Context, Envelope, json.RawMessage) error {
+		t.Error("UserRestored handler called for a UserDeleted message")
+		return nil
+	})
+
+	if err := d.Handle(envelopeMessage(t, UserDeletedType, UserDeletedPayload{ID: 7, Hard: true})); err != nil {
+		t.Fatal(err)
+	}
+	if gotType != UserDeletedType || got != (UserDeletedPayload{ID: 7, Hard: true}) {
+		t.Errorf("handler got %q %+v", gotType, got)
+	}
+}
+
+func TestDispatcher_UnknownTypePolicy(t *testing.T) {
+	msg := envelopeMessage(t, "SomethingNew", struct{}{})
+
+	if err := NewDispatcher(SkipUnknown, logging.NewNop()).Handle(msg); err != nil {
+		t.Errorf("SkipUnknown: %v", err)
+	}
+	if err := NewDispatcher(RejectUnknown, logging.NewNop()).Handle(msg); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("RejectUnknown: got %v, want ErrUnknownType", err)
+	}
+}
+
+func TestDispatcher_Errors(t *testing.T) {
+	d := NewDispatcher(SkipUnknown, logging.NewNop())
+	boom := errors.New("boom")
+	Register(d, UserDeletedType, func(context.Context, Envelope, UserDeletedPayload) error { return boom })
+
+	if err := d.Handle(message.NewMessage("1", []byte("not json"))); err == nil {
+		t.Error("expected an error for a malformed envelope")
+	}
+	if err := d.Handle(envelopeMessage(t, UserDeletedType, "not an object")); err == nil {
+		t.Error("expected an error for a payload of the wrong shape")
+	}
+	if err := d.Handle(envelopeMessage(t, UserDeletedType, UserDeletedPayload{ID: 1})); !errors.Is(err, boom) {
+		t.Errorf("got %v, want the handler's error", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a type twice should panic")
+		}
+	}()
+	Register(d, UserDeletedType, func(context.Context, Envelope, UserDeletedPayload) error { return nil })
+}
+
+// seenSet is a Deduplicator that remembers IDs in memory.
+type seenSet map[string]bool
+
+func (s seenSet) Once(ctx context.Context, messageID string, handle func(context.Context) error) (bool, error) {
+	if s[messageID] {
+		return true, nil
+	}
+	if err := handle(ctx); err != nil {
+		return false, err
+	}
+	s[messageID] = true
+	return false, nil
+}
+
+func TestDispatcher_DeduplicatesByMessageID(t *testing.T) {
+	d := NewDispatcher(SkipUnknown, logging.NewNop())
+	d.Deduplicate(seenSet{})
+
+	calls := 0
+	Register(d, UserDeletedType, func(context.Context, Envelope, UserDeletedPayload) error {
+		calls++
+		return nil
+	})
+
+	msg := envelopeMessage(t, UserDeletedType, UserDeletedPayload{ID: 7})
+	for range 2 {
+		if err := d.Handle(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times for a redelivered message, want 1", calls)
+	}
+}
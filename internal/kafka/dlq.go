@@ -0,0 +1,111 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/message/router/middleware"
+)
+
+// DLQSuffix is appended to a topic to name its dead-letter topic.
+const DLQSuffix = ".dlq"
+
+// Headers added to dead-lettered messages, next to the original ones.
+const (
+	DLQReasonHeader        = "dlq_reason"
+	DLQAttemptsHeader      = "dlq_attempts"
+	DLQOriginalTopicHeader = "dlq_original_topic"
+	DLQHandlerHeader       = "dlq_handler"
+	DLQFailedAtHeader      = "dlq_failed_at"
+)
+
+// RetryPolicy bounds how long a failing message is retried before it is
+// dead-lettered.
+type RetryPolicy struct {
+	MaxAttempts     int // including the first delivery
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+}
+
+// permanentError marks a failure retrying cannot fix.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying: the message goes straight to
+// the dead-letter topic. Malformed and unknown messages are permanent.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// deadLetterMiddlewares retries failed messages with exponential backoff and
+// then publishes them to topic+DLQSuffix, so one poison message cannot
+// block its partition. Outermost first, as Handler.AddMiddleware wants them.
+func deadLetterMiddlewares(handlerName, topic string, policy RetryPolicy, pub message.Publisher, logger watermill.LoggerAdapter) []message.HandlerMiddleware {
+	retry := middleware.Retry{
+		MaxRetries:          max(policy.MaxAttempts-1, 0),
+		InitialInterval:     policy.InitialInterval,
+		MaxInterval:         policy.MaxInterval,
+		Multiplier:          2,
+		RandomizationFactor: 0.5,
+		ResetContextOnRetry: true,
+		ShouldRetry: func(p middleware.RetryParams) bool {
+			return !IsPermanent(p.Err)
+		},
+		Logger: logger,
+	}
+
+	deadLetter := func(h message.HandlerFunc) message.HandlerFunc {
+		return func(msg *message.Message) ([]*message.Message, error) {
+			out, err := h(msg)
+			if err == nil {
+				return out, nil
+			}
+			// Shutting down is not the message's fault: nack it for redelivery.
+			if msg.Context().Err() != nil {
+				return nil, err
+			}
+
+			dead := msg.Copy()
+			dead.Metadata.Set(DLQReasonHeader, err.Error())
+			dead.Metadata.Set(DLQOriginalTopicHeader, topic)
+			dead.Metadata.Set(DLQHandlerHeader, handlerName)
+			dead.Metadata.Set(DLQFailedAtHeader, time.Now().UTC().Format(time.RFC3339Nano))
+			if pubErr := pub.Publish(topic+DLQSuffix, dead); pubErr != nil {
+				return nil, fmt.Errorf("dead-letter message %s: %w (handler error: %v)", msg.UUID, pubErr, err)
+			}
+			logger.Error("message dead-lettered", err, watermill.LogFields{
+				"topic":      topic,
+				"dlq_topic":  topic + DLQSuffix,
+				"message_id": msg.UUID,
+				"attempts":   dead.Metadata.Get(DLQAttemptsHeader),
+			})
+			return nil, nil
+		}
+	}
+
+	// Innermost: counts deliveries to the handler, retries included.
+	countAttempts := func(h message.HandlerFunc) message.HandlerFunc {
+		return func(msg *message.Message) ([]*message.Message, error) {
+			n, _ := strconv.Atoi(msg.Metadata.Get(DLQAttemptsHeader))
+			msg.Metadata.Set(DLQAttemptsHeader, strconv.Itoa(n+1))
+			return h(msg)
+		}
+	}
+
+	return []message.HandlerMiddleware{deadLetter, retry.Middleware, countAttempts}
+}
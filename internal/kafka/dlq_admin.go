@@ -0,0 +1,220 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"kabsa/internal/config"
+)
+
+// ErrNotDeadLetter is returned by DLQAdmin for topics without DLQSuffix.
+var ErrNotDeadLetter = errors.New("not a dead-letter topic")
+
+// DeadLetter is a message read back from a dead-letter topic.
+type DeadLetter struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Timestamp time.Time
+	Key       string
+
+	Reason        string
+	Attempts      int
+	OriginalTopic string
+	Handler       string
+	FailedAt      time.Time
+
+	// Headers holds every header, the dlq_* ones included.
+	Headers map[string]string
+	Payload []byte
+}
+
+// DLQAdmin reads dead-letter topics directly with Sarama, without joining a
+// consumer group, and replays their messages to the source topic.
+type DLQAdmin struct {
+	client   sarama.Client
+	consumer sarama.Consumer
+	producer sarama.SyncProducer
+}
+
+func NewDLQAdmin(cfg config.KafkaConfig) (*DLQAdmin, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("kafka is disabled")
+	}
+
+	sc, err := saramaConfig(sarama.NewConfig(), cfg)
+	if err != nil {
+		return nil, err
+	}
+	sc.Producer.Return.Successes = true
+
+	client, err := sarama.NewClient(cfg.Brokers, sc)
+	if err != nil {
+		return nil, fmt.Errorf("connect to kafka: %w", err)
+	}
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("create consumer: %w", err)
+	}
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		_ = consumer.Close()
+		_ = client.Close()
+		return nil, fmt.Errorf("create producer: %w", err)
+	}
+	return &DLQAdmin{client: client, consumer: consumer, producer: producer}, nil
+}
+
+func (a *DLQAdmin) Close() error {
+	return errors.Join(a.producer.Close(), a.consumer.Close(), a.client.Close())
+}
+
+// List returns up to limit of the newest messages of each partition of
+// topic, oldest first.
+func (a *DLQAdmin) List(topic string, limit int) ([]DeadLetter, error) {
+	if !strings.HasSuffix(topic, DLQSuffix) {
+		return nil, fmt.Errorf("%w: %s", ErrNotDeadLetter, topic)
+	}
+	partitions, err := a.client.Partitions(topic)
+	if err != nil {
+		return nil, fmt.Errorf("list partitions of %s: %w", topic, err)
+	}
+
+	var out []DeadLetter
+	for _, p := range partitions {
+		oldest, err := a.client.GetOffset(topic, p, sarama.OffsetOldest)
+		if err != nil {
+			return nil, fmt.Errorf("oldest offset of %s/%d: %w", topic, p, err)
+		}
+		newest, err := a.client.GetOffset(topic, p, sarama.OffsetNewest)
+		if err != nil {
+			return nil, fmt.Errorf("newest offset of %s/%d: %w", topic, p, err)
+		}
+		from := max(oldest, newest-int64(limit))
+		if from >= newest {
+			continue
+		}
+		msgs, err := a.read(topic, p, from, newest-from)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range msgs {
+			out = append(out, toDeadLetter(m))
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Timestamp.Before(out[j].Timestamp) })
+	if len(out) > limit {
+		out = out[len(out)-limit:]
+	}
+	return out, nil
+}
+
+// Get returns the message at partition/offset of topic.
+func (a *DLQAdmin) Get(topic string, partition int32, offset int64) (DeadLetter, error) {
+	if !strings.HasSuffix(topic, DLQSuffix) {
+		return DeadLetter{}, fmt.Errorf("%w: %s", ErrNotDeadLetter, topic)
+	}
+	newest, err := a.client.GetOffset(topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return DeadLetter{}, fmt.Errorf("newest offset of %s/%d: %w", topic, partition, err)
+	}
+	if offset >= newest {
+		return DeadLetter{}, fmt.Errorf("offset %d of %s/%d does not exist yet", offset, topic, partition)
+	}
+	msgs, err := a.read(topic, partition, offset, 1)
+	if err != nil {
+		return DeadLetter{}, err
+	}
+	return toDeadLetter(msgs[0]), nil
+}
+
+// Replay publishes the message at partition/offset of a dead-letter topic
+// back to its original topic, with the same key and payload and without the
+// dlq_* headers, so it gets a fresh set of attempts. The dead letter itself
+// stays where it is. Returns the topic it was sent to.
+func (a *DLQAdmin) Replay(topic string, partition int32, offset int64) (string, error) {
+	dl, err := a.Get(topic, partition, offset)
+	if err != nil {
+		return "", err
+	}
+	target := dl.OriginalTopic
+	if target == "" {
+		target = strings.TrimSuffix(topic, DLQSuffix)
+	}
+
+	msg := &sarama.ProducerMessage{Topic: target, Value: sarama.ByteEncoder(dl.Payload)}
+	if dl.Key != "" {
+		msg.Key = sarama.StringEncoder(dl.Key)
+	}
+	for k, v := range dl.Headers {
+		if strings.HasPrefix(k, "dlq_") {
+			continue
+		}
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+	}
+	if _, _, err := a.producer.SendMessage(msg); err != nil {
+		return "", fmt.Errorf("replay %s/%d@%d to %s: %w", topic, partition, offset, target, err)
+	}
+	return target, nil
+}
+
+// read consumes n messages of topic/partition starting at offset.
+func (a *DLQAdmin) read(topic string, partition int32, offset, n int64) ([]*sarama.ConsumerMessage, error) {
+	pc, err := a.consumer.ConsumePartition(topic, partition, offset)
+	if err != nil {
+		return nil, fmt.Errorf("consume %s/%d@%d: %w", topic, partition, offset, err)
+	}
+	defer pc.Close()
+
+	timeout := time.NewTimer(10 * time.Second)
+	defer timeout.Stop()
+
+	msgs := make([]*sarama.ConsumerMessage, 0, n)
+	for int64(len(msgs)) < n {
+		select {
+		case m := <-pc.Messages():
+			// Compacted or transactional topics can skip offsets; stop at
+			// the end of the requested range either way.
+			if m.Offset >= offset+n {
+				return msgs, nil
+			}
+			msgs = append(msgs, m)
+		case err := <-pc.Errors():
+			return nil, fmt.Errorf("consume %s/%d: %w", topic, partition, err)
+		case <-timeout.C:
+			return nil, fmt.Errorf("timed out reading %s/%d@%d", topic, partition, offset)
+		}
+	}
+	return msgs, nil
+}
+
+func toDeadLetter(m *sarama.ConsumerMessage) DeadLetter {
+	headers := make(map[string]string, len(m.Headers))
+	for _, h := range m.Headers {
+		headers[string(h.Key)] = string(h.Value)
+	}
+	attempts, _ := strconv.Atoi(headers[DLQAttemptsHeader])
+	failedAt, _ := time.Parse(time.RFC3339Nano, headers[DLQFailedAtHeader])
+
+	return DeadLetter{
+		Topic:         m.Topic,
+		Partition:     m.Partition,
+		Offset:        m.Offset,
+		Timestamp:     m.Timestamp,
+		Key:           string(m.Key),
+		Reason:        headers[DLQReasonHeader],
+		Attempts:      attempts,
+		OriginalTopic: headers[DLQOriginalTopicHeader],
+		Handler:       headers[DLQHandlerHeader],
+		FailedAt:      failedAt,
+		Headers:       headers,
+		Payload:       m.Value,
+	}
+}
@@ -0,0 +1,143 @@
+package kafka
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+
+	"kabsa/internal/logging"
+)
+
+type fakePublisher struct {
+	err       error
+	published map[string][]*message.Message
+}
+
+func (p *fakePublisher) Publish(topic string, msgs ...*message.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.published == nil {
+		p.published = make(map[string][]*message.Message)
+	}
+	p.published[topic] = append(p.published[topic], msgs...)
+	return nil
+}
+
+func (p *fakePublisher) Close() error { return nil }
+
+// withDeadLetter wraps h the way Router.Handle does, with instant retries.
+func withDeadLetter(pub message.Publisher, maxAttempts int, h message.NoPublishHandlerFunc) message.HandlerFunc {
+	policy := RetryPolicy{MaxAttempts: maxAttempts, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+	mws := deadLetterMiddlewares("test-handler", "users", policy, pub, watermill.NopLogger{})
+
+	handler := func(msg *message.Message) ([]*message.Message, error) { return nil, h(msg) }
+	for i := len(mws) - 1; i >= 0; i-- {
+		handler = mws[i](handler)
+	}
+	return handler
+}
+
+func TestDeadLetter_RetriesTransientFailures(t *testing.T) {
+	pub := &fakePublisher{}
+	calls := 0
+	h := withDeadLetter(pub, 3, func(*message.Message) error {
+		calls++
+		if calls < 3 {
+			return errors.New("redis down")
+		}
+		return nil
+	})
+
+	if _, err := h(message.NewMessage("m1", []byte("{}"))); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("handler called %d times, want 3", calls)
+	}
+	if len(pub.published) != 0 {
+		t.Errorf("published to DLQ after a successful retry: %v", pub.published)
+	}
+}
+
+func TestDeadLetter_PublishesExhaustedMessages(t *testing.T) {
+	pub := &fakePublisher{}
+	calls := 0
+	h := withDeadLetter(pub, 3, func(*message.Message) error {
+		calls++
+		return errors.New("redis down")
+	})
+
+	msg := message.NewMessage("m1", []byte(`{"type":"UserDeleted"}`))
+	msg.Metadata.Set(partitionKeyHeader, "42")
+	if _, err := h(msg); err != nil {
+		t.Fatalf("dead-lettered message should be acked, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("handler called %d times, want 3", calls)
+	}
+
+	dead := pub.published["users"+DLQSuffix]
+	if len(dead) != 1 {
+		t.Fatalf("got %d dead letters, want 1", len(dead))
+	}
+	got := dead[0]
+	if got.UUID != "m1" || string(got.Payload) != `{"type":"UserDeleted"}` {
+		t.Errorf("dead letter %s %s does not match the original", got.UUID, got.Payload)
+	}
+	for header, want := range map[string]string{
+		partitionKeyHeader:     "42",
+		DLQAttemptsHeader:      "3",
+		DLQOriginalTopicHeader: "users",
+		DLQHandlerHeader:       "test-handler",
+	} {
+		if v := got.Metadata.Get(header); v != want {
+			t.Errorf("header %s = %q, want %q", header, v, want)
+		}
+	}
+	if !strings.Contains(got.Metadata.Get(DLQReasonHeader), "redis down") {
+		t.Errorf("reason = %q", got.Metadata.Get(DLQReasonHeader))
+	}
+	if _, err := time.Parse(time.RFC3339Nano, got.Metadata.Get(DLQFailedAtHeader)); err != nil {
+		t.Errorf("failed at: %v", err)
+	}
+}
+
+func TestDeadLetter_PermanentErrorsSkipRetries(t *testing.T) {
+	pub := &fakePublisher{}
+	calls := 0
+	h := withDeadLetter(pub, 5, func(*message.Message) error {
+		calls++
+		return Permanent(errors.New("bad payload"))
+	})
+
+	if _, err := h(message.NewMessage("m1", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+	if dead := pub.published["users"+DLQSuffix]; len(dead) != 1 || dead[0].Metadata.Get(DLQAttemptsHeader) != "1" {
+		t.Errorf("dead letters: %v", dead)
+	}
+}
+
+func TestDeadLetter_NacksWhenDLQPublishFails(t *testing.T) {
+	pub := &fakePublisher{err: errors.New("broker unavailable")}
+	h := withDeadLetter(pub, 1, func(*message.Message) error { return errors.New("redis down") })
+
+	if _, err := h(message.NewMessage("m1", nil)); err == nil {
+		t.Fatal("want an error so the message is redelivered")
+	}
+}
+
+func TestDispatcher_MalformedMessagesArePermanent(t *testing.T) {
+	d := NewDispatcher(RejectUnknown, logging.NewNop())
+	if err := d.Handle(message.NewMessage("m1", []byte("not json"))); !IsPermanent(err) {
+		t.Errorf("malformed envelope: got %v, want a permanent error", err)
+	}
+}
@@ -2,7 +2,10 @@
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type Envelope struct {
@@ -12,3 +15,18 @@ type Envelope struct {
 	OccurredAt    time.Time       `json:"occurredAt"`
 	Payload       json.RawMessage `json:"payload"`
 }
+
+// NewEnvelope wraps payload, serialized as JSON, in an envelope with a fresh
+// MessageID.
+func NewEnvelope(msgType string, payload any) (Envelope, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("marshal payload: %w", err)
+	}
+	return Envelope{
+		MessageID:  uuid.NewString(),
+		Type:       msgType,
+		OccurredAt: time.Now().UTC(),
+		Payload:    payloadBytes,
+	}, nil
+}
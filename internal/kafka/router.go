@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/IBM/sarama"
+	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill-kafka/v3/pkg/kafka"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/garsue/watermillzap"
@@ -13,7 +14,12 @@ import (
 )
 
 type Router struct {
-	router *message.Router
+	router     *message.Router
+	subscriber message.Subscriber
+	// dlq publishes messages that exhausted their retries.
+	dlq      message.Publisher
+	retry    RetryPolicy
+	wmlogger watermill.LoggerAdapter
 }
 
 func NewRouter(
@@ -33,11 +39,17 @@ func NewRouter(
 		return nil, fmt.Errorf("create watermill router: %w", err)
 	}
 
+	saramaCfg, err := saramaConfig(kafka.DefaultSaramaSubscriberConfig(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// Subscriber config
 	subCfg := kafka.SubscriberConfig{
-		Brokers:       cfg.Brokers,
-		Unmarshaler:   kafka.DefaultMarshaler{},
-		ConsumerGroup: cfg.GroupID,
+		Brokers:               cfg.Brokers,
+		Unmarshaler:           kafka.DefaultMarshaler{},
+		OverwriteSaramaConfig: saramaCfg,
+		ConsumerGroup:         cfg.GroupID,
 		InitializeTopicDetails: &sarama.TopicDetail{
 			NumPartitions:     3,
 			ReplicationFactor: 1,
@@ -51,26 +63,37 @@ func NewRouter(
 		return nil, fmt.Errorf("create kafka subscriber: %w", err)
 	}
 
-	// Example: register a handler for users topic (you can refactor into separate file)
-	usersTopic := cfg.TopicPrefix + "users"
+	dlq, err := newPublisher(cfg, wmlogger)
+	if err != nil {
+		_ = subscriber.Close()
+		return nil, fmt.Errorf("create dead-letter publisher: %w", err)
+	}
 
-	router.AddHandler(
-		"user-events-handler", // handler name
-		usersTopic,            // input topic
-		subscriber,
-		"",  // no output topic, we're just handling side-effects
-		nil, // no publisher (no out topic)
-		func(msg *message.Message) ([]*message.Message, error) {
-			// TODO: decode Envelope + dispatch to Go service or log
-			baseLogger.Info("received message",
-				"topic", usersTopic,
-				"uuid", msg.UUID,
-			)
-			return nil, nil
+	return &Router{
+		router:     router,
+		subscriber: subscriber,
+		dlq:        dlq,
+		retry: RetryPolicy{
+			MaxAttempts:     cfg.RetryMaxAttempts,
+			InitialInterval: cfg.RetryInitialInterval,
+			MaxInterval:     cfg.RetryMaxInterval,
 		},
-	)
+		wmlogger: wmlogger,
+	}, nil
+}
 
-	return &Router{router: router}, nil
+// Handle consumes topic with d under the given handler name, which must be
+// unique per router. Each message is handled in a consumer span continuing
+// the producer's trace. Failures are retried per the configured RetryPolicy
+// and then moved to topic+DLQSuffix. Call it before Run; with Kafka disabled
+// it does nothing.
+func (r *Router) Handle(name, topic string, d *Dispatcher) {
+	if r.router == nil {
+		return
+	}
+	h := r.router.AddNoPublisherHandler(name, topic, r.subscriber, d.Handle)
+	h.AddMiddleware(traceMiddleware(name, topic))
+	h.AddMiddleware(deadLetterMiddlewares(name, topic, r.retry, r.dlq, r.wmlogger)...)
 }
 
 func (r *Router) Run(ctx context.Context) error {
@@ -85,5 +108,7 @@ func (r *Router) Close(ctx context.Context) error {
 		return nil
 	}
 	_ = r.router.Close()
-	return nil
+	// The router stops its handlers before returning, so nothing publishes
+	// to the DLQ any more.
+	return r.dlq.Close()
 }
@@ -0,0 +1,122 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/IBM/sarama"
+	"github.com/xdg-go/scram"
+
+	"kabsa/internal/config"
+)
+
+// saramaConfig applies the client ID and the TLS and SASL settings of cfg
+// to base, one of the Sarama defaults for the client being built. Every
+// Kafka client of the service goes through it, so they all connect the
+// same way.
+func saramaConfig(base *sarama.Config, cfg config.KafkaConfig) (*sarama.Config, error) {
+	if cfg.ClientID != "" {
+		base.ClientID = cfg.ClientID
+	}
+
+	if cfg.TLS.Enabled {
+		tlsCfg, err := tlsConfig(cfg.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("kafka tls: %w", err)
+		}
+		base.Net.TLS.Enable = true
+		base.Net.TLS.Config = tlsCfg
+	}
+
+	if cfg.SASL.Mechanism != "" {
+		if err := applySASL(base, cfg.SASL); err != nil {
+			return nil, fmt.Errorf("kafka sasl: %w", err)
+		}
+	}
+	return base, nil
+}
+
+func tlsConfig(cfg config.KafkaTLSConfig) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, // opt-in, for local brokers
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in CA file %s", cfg.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, errors.New("client certificate needs both a cert file and a key file")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
+
+func applySASL(c *sarama.Config, cfg config.KafkaSASLConfig) error {
+	if cfg.Username == "" {
+		return errors.New("username is required")
+	}
+
+	mechanism := sarama.SASLMechanism(strings.ToUpper(cfg.Mechanism))
+	switch mechanism {
+	case sarama.SASLTypePlaintext:
+	case sarama.SASLTypeSCRAMSHA256:
+		c.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: scram.SHA256}
+		}
+	case sarama.SASLTypeSCRAMSHA512:
+		c.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: scram.SHA512}
+		}
+	default:
+		return fmt.Errorf("unsupported mechanism %q (want PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512)", cfg.Mechanism)
+	}
+
+	c.Net.SASL.Enable = true
+	c.Net.SASL.Mechanism = mechanism
+	c.Net.SASL.User = cfg.Username
+	c.Net.SASL.Password = cfg.Password
+	return nil
+}
+
+// scramClient adapts xdg-go/scram to sarama.SCRAMClient.
+type scramClient struct {
+	hashGenerator scram.HashGeneratorFcn
+	conversation  *scram.ClientConversation
+}
+
+func (c *scramClient) Begin(userName, password, authzID string) error {
+	client, err := c.hashGenerator.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+	c.conversation = client.NewConversation()
+	return nil
+}
+
+func (c *scramClient) Step(challenge string) (string, error) {
+	return c.conversation.Step(challenge)
+}
+
+func (c *scramClient) Done() bool {
+	return c.conversation.Done()
+}
@@ -0,0 +1,123 @@
+package kafka
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"kabsa/internal/config"
+)
+
+// writeSelfSigned writes a self-signed certificate and its key as PEM files.
+func writeSelfSigned(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kabsa-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestSaramaConfig_TLSAndSCRAM(t *testing.T) {
+	certFile, keyFile := writeSelfSigned(t)
+	cfg := config.KafkaConfig{
+		ClientID: "kabsa-api",
+		TLS: config.KafkaTLSConfig{
+			Enabled:  true,
+			CAFile:   certFile,
+			CertFile: certFile,
+			KeyFile:  keyFile,
+		},
+		SASL: config.KafkaSASLConfig{Mechanism: "scram-sha-512", Username: "svc", Password: "secret"},
+	}
+
+	c, err := saramaConfig(sarama.NewConfig(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("invalid sarama config: %v", err)
+	}
+	if c.ClientID != "kabsa-api" {
+		t.Errorf("ClientID = %q", c.ClientID)
+	}
+	if !c.Net.TLS.Enable || c.Net.TLS.Config.RootCAs == nil || len(c.Net.TLS.Config.Certificates) != 1 {
+		t.Errorf("TLS not fully configured: %+v", c.Net.TLS)
+	}
+	if !c.Net.SASL.Enable || c.Net.SASL.Mechanism != sarama.SASLTypeSCRAMSHA512 || c.Net.SASL.User != "svc" {
+		t.Errorf("SASL = %+v", c.Net.SASL)
+	}
+
+	// The generated client completes the first step of a SCRAM exchange.
+	scram := c.Net.SASL.SCRAMClientGeneratorFunc()
+	if err := scram.Begin("svc", "secret", ""); err != nil {
+		t.Fatal(err)
+	}
+	if first, err := scram.Step(""); err != nil || first == "" {
+		t.Errorf("client-first message = %q, err = %v", first, err)
+	}
+}
+
+func TestSaramaConfig_Plain(t *testing.T) {
+	c, err := saramaConfig(sarama.NewConfig(), config.KafkaConfig{
+		SASL: config.KafkaSASLConfig{Mechanism: "PLAIN", Username: "svc", Password: "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("invalid sarama config: %v", err)
+	}
+	if c.Net.SASL.Mechanism != sarama.SASLTypePlaintext || c.Net.TLS.Enable {
+		t.Errorf("SASL = %+v, TLS enabled = %v", c.Net.SASL, c.Net.TLS.Enable)
+	}
+}
+
+func TestSaramaConfig_RejectsBadSettings(t *testing.T) {
+	certFile, _ := writeSelfSigned(t)
+	for name, cfg := range map[string]config.KafkaConfig{
+		"unknown mechanism": {SASL: config.KafkaSASLConfig{Mechanism: "GSSAPI", Username: "svc"}},
+		"missing username":  {SASL: config.KafkaSASLConfig{Mechanism: "PLAIN"}},
+		"cert without key":  {TLS: config.KafkaTLSConfig{Enabled: true, CertFile: certFile}},
+		"missing CA file":   {TLS: config.KafkaTLSConfig{Enabled: true, CAFile: "/nonexistent/ca.pem"}},
+	} {
+		if _, err := saramaConfig(sarama.NewConfig(), cfg); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "kabsa/internal/kafka"
+
+// Message headers that carry the producer's context to consumers, next to
+// the W3C traceparent and tracestate.
+const (
+	RequestIDHeader     = "requestId"
+	CorrelationIDHeader = "correlationId"
+)
+
+// propagator is fixed to W3C trace context rather than the global one, so
+// the headers don't depend on how telemetry was set up.
+var propagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// ContextHeaders returns the trace context and chi request ID of ctx as
+// message headers. The outbox stores them with a row so the relay, running
+// outside the request, can publish under the request's trace.
+func ContextHeaders(ctx context.Context) map[string]string {
+	headers := make(map[string]string)
+	propagator.Inject(ctx, propagation.MapCarrier(headers))
+	if id := middleware.GetReqID(ctx); id != "" {
+		headers[RequestIDHeader] = id
+	}
+	return headers
+}
+
+// WithContextHeaders returns ctx carrying the trace context and request ID
+// found in headers, as set by ContextHeaders.
+func WithContextHeaders(ctx context.Context, headers map[string]string) context.Context {
+	ctx = propagator.Extract(ctx, propagation.MapCarrier(headers))
+	if id := headers[RequestIDHeader]; id != "" {
+		ctx = context.WithValue(ctx, middleware.RequestIDKey, id)
+	}
+	return ctx
+}
+
+// correlationID is the request ID of ctx or, outside a request, its trace
+// ID. Consumers that don't speak W3C trace context group messages by it.
+func correlationID(ctx context.Context) string {
+	if id := middleware.GetReqID(ctx); id != "" {
+		return id
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
+
+func messagingAttrs(topic string, msgID string) trace.SpanStartOption {
+	return trace.WithAttributes(
+		attribute.String("messaging.system", "kafka"),
+		attribute.String("messaging.destination.name", topic),
+		attribute.String("messaging.message.id", msgID),
+	)
+}
+
+// traceMiddleware continues the producer's trace in a consumer span around
+// the handler, and restores the producer's request ID for logs.
+func traceMiddleware(handlerName, topic string) message.HandlerMiddleware {
+	tracer := otel.Tracer(tracerName)
+	return func(h message.HandlerFunc) message.HandlerFunc {
+		return func(msg *message.Message) ([]*message.Message, error) {
+			ctx := WithContextHeaders(msg.Context(), msg.Metadata)
+			ctx, span := tracer.Start(ctx, topic+" process",
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				messagingAttrs(topic, msg.UUID),
+				trace.WithAttributes(
+					attribute.String("messaging.operation.type", "process"),
+					attribute.String("messaging.consumer.handler", handlerName),
+				),
+			)
+			defer span.End()
+			msg.SetContext(ctx)
+
+			out, err := h(msg)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return out, err
+		}
+	}
+}
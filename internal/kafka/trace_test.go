@@ -0,0 +1,80 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceMiddleware_ContinuesProducerTrace(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	// The producing HTTP request.
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "host/abc-000001")
+	ctx, producer := otel.Tracer("test").Start(ctx, "POST /users")
+	headers := ContextHeaders(ctx)
+	producer.End()
+
+	if headers["traceparent"] == "" || headers[RequestIDHeader] != "host/abc-000001" {
+		t.Fatalf("headers = %v, want traceparent and request ID", headers)
+	}
+
+	msg := message.NewMessage("m1", nil)
+	for k, v := range headers {
+		msg.Metadata.Set(k, v)
+	}
+
+	var handlerCtx context.Context
+	h := traceMiddleware("test-handler", "users")(func(msg *message.Message) ([]*message.Message, error) {
+		handlerCtx = msg.Context()
+		return nil, nil
+	})
+	if _, err := h(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := middleware.GetReqID(handlerCtx); got != "host/abc-000001" {
+		t.Errorf("request ID in handler = %q", got)
+	}
+	spans := rec.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	consumer := spans[1]
+	if consumer.SpanKind() != trace.SpanKindConsumer || consumer.Name() != "users process" {
+		t.Errorf("consumer span = %q (%v)", consumer.Name(), consumer.SpanKind())
+	}
+	if consumer.Parent().SpanID() != producer.SpanContext().SpanID() ||
+		consumer.SpanContext().TraceID() != producer.SpanContext().TraceID() {
+		t.Error("consumer span is not a child of the producer span")
+	}
+	if trace.SpanContextFromContext(handlerCtx).SpanID() != consumer.SpanContext().SpanID() {
+		t.Error("handler does not run in the consumer span")
+	}
+}
+
+func TestCorrelationID_FallsBackToTraceID(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "job")
+	defer span.End()
+
+	if got, want := correlationID(ctx), span.SpanContext().TraceID().String(); got != want {
+		t.Errorf("without request ID: %q, want trace ID %q", got, want)
+	}
+	ctx = context.WithValue(ctx, middleware.RequestIDKey, "req-1")
+	if got := correlationID(ctx); got != "req-1" {
+		t.Errorf("with request ID: %q, want req-1", got)
+	}
+	if got := correlationID(context.Background()); got != "" {
+		t.Errorf("empty context: %q", got)
+	}
+}
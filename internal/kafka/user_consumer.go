@@ -0,0 +1,30 @@
+package kafka
+
+import (
+	"context"
+	appuser "kabsa/internal/app/user"
+	"kabsa/internal/cache"
+)
+
+// RegisterUserCacheEviction evicts a user's cache entry on every event that
+// changes the user. The service refreshes the cache after each write, but
+// only best-effort; this catches entries left stale when that failed or
+// when the write came from another instance or the CLI.
+func RegisterUserCacheEviction(d *Dispatcher, userCache cache.UserCache) {
+	evict := func(ctx context.Context, id int64) error {
+		return userCache.Delete(ctx, id)
+	}
+
+	Register(d, UserUpdatedType, func(ctx context.Context, _ Envelope, u appuser.UserDto) error {
+		return evict(ctx, u.Id)
+	})
+	Register(d, UserEmailChangedType, func(ctx context.Context, _ Envelope, p UserEmailChangedPayload) error {
+		return evict(ctx, p.ID)
+	})
+	Register(d, UserDeletedType, func(ctx context.Context, _ Envelope, p UserDeletedPayload) error {
+		return evict(ctx, p.ID)
+	})
+	Register(d, UserRestoredType, func(ctx context.Context, _ Envelope, u appuser.UserDto) error {
+		return evict(ctx, u.Id)
+	})
+}
@@ -6,14 +6,33 @@ import (
 	appuser "kabsa/internal/app/user"
 	"kabsa/internal/config"
 	"kabsa/internal/logging"
+	"strconv"
 )
 
+// Event types on the users topic. UserCreated, UserUpdated and UserRestored
+// carry an appuser.UserDto; the others have the payload types below.
 const (
-	UserCreatedType = "UserCreated"
-	UserUpdatedType = "UserUpdated"
-	UserDeletedType = "UserDeleted"
+	UserCreatedType      = "UserCreated"
+	UserUpdatedType      = "UserUpdated"
+	UserEmailChangedType = "UserEmailChanged"
+	UserDeletedType      = "UserDeleted"
+	UserRestoredType     = "UserRestored"
 )
 
+// UserEmailChangedPayload is the payload of UserEmailChanged events.
+type UserEmailChangedPayload struct {
+	ID       int64  `json:"id"`
+	OldEmail string `json:"oldEmail"`
+	NewEmail string `json:"newEmail"`
+}
+
+// UserDeletedPayload is the payload of UserDeleted events. Hard is false for
+// soft deletes, which UserRestored can undo.
+type UserDeletedPayload struct {
+	ID   int64 `json:"id"`
+	Hard bool  `json:"hard"`
+}
+
 type userEvents struct {
 	bus         Bus
 	topicPrefix string
@@ -32,27 +51,46 @@ func (e *userEvents) topic() string {
 	return e.topicPrefix + "users"
 }
 
+// key partitions user events by user, keeping each user's events in order.
+func (e *userEvents) key(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
 func (e *userEvents) UserCreated(ctx context.Context, u *appuser.UserDto) error {
-	if err := e.bus.Publish(ctx, e.topic(), UserCreatedType, u); err != nil {
+	if err := e.bus.Publish(ctx, e.topic(), e.key(u.Id), UserCreatedType, u); err != nil {
 		return fmt.Errorf("publish UserCreated: %w", err)
 	}
 	return nil
 }
 
 func (e *userEvents) UserUpdated(ctx context.Context, u *appuser.UserDto) error {
-	if err := e.bus.Publish(ctx, e.topic(), UserUpdatedType, u); err != nil {
+	if err := e.bus.Publish(ctx, e.topic(), e.key(u.Id), UserUpdatedType, u); err != nil {
 		return fmt.Errorf("publish UserUpdated: %w", err)
 	}
 	return nil
 }
 
-func (e *userEvents) UserDeleted(ctx context.Context, id int64) error {
-	payload := struct {
-		ID int64 `json:"id"`
-	}{ID: id}
+func (e *userEvents) UserEmailChanged(ctx context.Context, id int64, oldEmail, newEmail string) error {
+	payload := UserEmailChangedPayload{ID: id, OldEmail: oldEmail, NewEmail: newEmail}
 
-	if err := e.bus.Publish(ctx, e.topic(), UserDeletedType, payload); err != nil {
+	if err := e.bus.Publish(ctx, e.topic(), e.key(id), UserEmailChangedType, payload); err != nil {
+		return fmt.Errorf("publish UserEmailChanged: %w", err)
+	}
+	return nil
+}
+
+func (e *userEvents) UserDeleted(ctx context.Context, id int64, hard bool) error {
+	payload := UserDeletedPayload{ID: id, Hard: hard}
+
+	if err := e.bus.Publish(ctx, e.topic(), e.key(id), UserDeletedType, payload); err != nil {
 		return fmt.Errorf("publish UserDeleted: %w", err)
 	}
 	return nil
 }
+
+func (e *userEvents) UserRestored(ctx context.Context, u *appuser.UserDto) error {
+	if err := e.bus.Publish(ctx, e.topic(), e.key(u.Id), UserRestoredType, u); err != nil {
+		return fmt.Errorf("publish UserRestored: %w", err)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 // We use key-value style args similar to zap.SugaredLogger.
 type Logger interface {
 	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
 	Error(msg string, args ...any)
 	Debug(msg string, args ...any)
 	With(args ...any) Logger
@@ -37,10 +38,19 @@ func New(serviceName, env string) Logger {
 	return &zapLogger{s: s}
 }
 
+// NewNop returns a Logger that discards everything; handy in tests.
+func NewNop() Logger {
+	return &zapLogger{s: zap.NewNop().Sugar()}
+}
+
 func (l *zapLogger) Info(msg string, args ...any) {
 	l.s.Infow(msg, args...)
 }
 
+func (l *zapLogger) Warn(msg string, args ...any) {
+	l.s.Warnw(msg, args...)
+}
+
 func (l *zapLogger) Error(msg string, args ...any) {
 	l.s.Errorw(msg, args...)
 }
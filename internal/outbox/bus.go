@@ -0,0 +1,52 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"kabsa/internal/db"
+	"kabsa/internal/kafka"
+)
+
+// Bus is a kafka.Bus that stores messages in the outbox table instead of
+// sending them. Inside db.Client.WithTx the row is written in that
+// transaction, so the message exists if and only if the change committed.
+// Relay sends the rows on, under the trace and request ID of the writer.
+type Bus struct {
+	client *db.Client
+}
+
+var _ kafka.Bus = (*Bus)(nil)
+
+func NewBus(client *db.Client) *Bus {
+	return &Bus{client: client}
+}
+
+func (b *Bus) Publish(ctx context.Context, topic string, key string, msgType string, payload any) error {
+	env, err := kafka.NewEnvelope(msgType, payload)
+	if err != nil {
+		return err
+	}
+	return b.PublishEnvelope(ctx, topic, key, env)
+}
+
+func (b *Bus) PublishEnvelope(ctx context.Context, topic string, key string, env kafka.Envelope) error {
+	headers := kafka.ContextHeaders(ctx)
+	if env.CorrelationID != "" {
+		headers[kafka.CorrelationIDHeader] = env.CorrelationID
+	}
+
+	err := b.client.EntFor(ctx).OutboxMessage.
+		Create().
+		SetMessageID(env.MessageID).
+		SetTopic(topic).
+		SetAggregateKey(key).
+		SetType(env.Type).
+		SetPayload(env.Payload).
+		SetHeaders(headers).
+		SetCreatedAt(env.OccurredAt).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("ent.OutboxMessage.Create: %w", err)
+	}
+	return nil
+}
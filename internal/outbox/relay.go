@@ -0,0 +1,213 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"kabsa/ent"
+	"kabsa/ent/outboxmessage"
+	"kabsa/internal/db"
+	"kabsa/internal/kafka"
+	"kabsa/internal/logging"
+	"time"
+)
+
+// relayLockKey is the advisory lock that elects the single relay instance.
+// One publisher at a time is what keeps per-key order across pods.
+const relayLockKey int64 = 0x6f7574626f78 // "outbox"
+
+type RelayOptions struct {
+	PollInterval time.Duration // how often to look for pending rows
+	BatchSize    int           // rows read per poll
+	MaxBackoff   time.Duration // cap on the retry delay after failed publishes
+	Retention    time.Duration // how long published rows are kept
+}
+
+// Relay publishes pending outbox rows to Kafka in id order. A row that fails
+// is retried with exponential backoff and holds back later rows with the
+// same aggregate key; other keys keep flowing. Delivery is at least once:
+// consumers dedup on Envelope.MessageID.
+type Relay struct {
+	client    *db.Client
+	publisher kafka.Bus
+	opts      RelayOptions
+	logger    logging.Logger
+}
+
+func NewRelay(client *db.Client, publisher kafka.Bus, opts RelayOptions, logger logging.Logger) *Relay {
+	return &Relay{
+		client:    client,
+		publisher: publisher,
+		opts:      opts,
+		logger:    logger.With("component", "outbox_relay"),
+	}
+}
+
+// Run relays until ctx is cancelled. Only the instance holding the advisory
+// lock publishes; the others keep trying to take it over. The lock is
+// re-checked every tick: if its connection was lost another instance may
+// already have taken over, and two publishers would break per-key order.
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	var (
+		lock        *db.Lock
+		lastCleanup time.Time
+	)
+	defer func() {
+		if lock != nil {
+			lock.Release()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if lock != nil {
+			if held, err := lock.Held(ctx); err != nil || !held {
+				if ctx.Err() != nil {
+					return nil
+				}
+				r.logger.Error("outbox relay lock lost, pausing until it is retaken", "error", err)
+				lock.Release()
+				lock = nil
+			}
+		}
+		if lock == nil {
+			taken, ok, err := r.client.TryAdvisoryLock(ctx, relayLockKey)
+			if err != nil {
+				r.logger.Error("failed to take outbox relay lock", "error", err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			lock = taken
+			r.logger.Info("outbox relay lock acquired")
+		}
+
+		// Drain: keep going while full batches come back.
+		for {
+			n, err := r.RelayOnce(ctx)
+			if err != nil {
+				r.logger.Error("outbox relay failed", "error", err)
+				break
+			}
+			if n < r.opts.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		if time.Since(lastCleanup) > time.Hour {
+			lastCleanup = time.Now()
+			if n, err := r.Cleanup(ctx); err != nil {
+				r.logger.Error("outbox cleanup failed", "error", err)
+			} else if n > 0 {
+				r.logger.Info("deleted published outbox rows", "count", n)
+			}
+		}
+	}
+}
+
+// RelayOnce handles one batch of pending rows and returns how many it read.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	rows, err := r.client.Ent().OutboxMessage.
+		Query().
+		Where(outboxmessage.PublishedAtIsNil()).
+		Order(ent.Asc(outboxmessage.FieldID)).
+		Limit(r.opts.BatchSize).
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ent.OutboxMessage.Query: %w", err)
+	}
+
+	now := time.Now()
+	blocked := make(map[string]bool) // keys with an earlier row still pending
+
+	for _, row := range rows {
+		if blocked[row.AggregateKey] {
+			continue
+		}
+		if row.AvailableAt.After(now) {
+			blocked[row.AggregateKey] = true
+			continue
+		}
+
+		env := kafka.Envelope{
+			MessageID:     row.MessageID,
+			CorrelationID: row.Headers[kafka.CorrelationIDHeader],
+			Type:          row.Type,
+			OccurredAt:    row.CreatedAt.UTC(),
+			Payload:       row.Payload,
+		}
+		// Publish as part of the writer's trace, not the relay's.
+		pubCtx := kafka.WithContextHeaders(ctx, row.Headers)
+		if err := r.publisher.PublishEnvelope(pubCtx, row.Topic, row.AggregateKey, env); err != nil {
+			blocked[row.AggregateKey] = true
+			r.markFailed(ctx, row, err)
+			continue
+		}
+
+		err := r.client.Ent().OutboxMessage.
+			UpdateOneID(row.ID).
+			SetPublishedAt(time.Now()).
+			AddAttempts(1).
+			Exec(ctx)
+		if err != nil {
+			// The message went out; it will go out again next poll, which
+			// consumers tolerate. Stop so later rows don't overtake it.
+			return len(rows), fmt.Errorf("mark outbox row %d published: %w", row.ID, err)
+		}
+	}
+
+	return len(rows), nil
+}
+
+func (r *Relay) markFailed(ctx context.Context, row *ent.OutboxMessage, cause error) {
+	attempts := row.Attempts + 1
+	delay := r.backoff(attempts)
+
+	r.logger.Error("failed to relay outbox message",
+		"error", cause,
+		"id", row.ID,
+		"messageId", row.MessageID,
+		"topic", row.Topic,
+		"attempts", attempts,
+		"retryIn", delay.String(),
+	)
+
+	err := r.client.Ent().OutboxMessage.
+		UpdateOneID(row.ID).
+		SetAttempts(attempts).
+		SetLastError(cause.Error()).
+		SetAvailableAt(time.Now().Add(delay)).
+		Exec(ctx)
+	if err != nil {
+		r.logger.Error("failed to record outbox failure", "error", err, "id", row.ID)
+	}
+}
+
+// backoff doubles from one poll interval per attempt up to MaxBackoff.
+func (r *Relay) backoff(attempts int) time.Duration {
+	d := r.opts.PollInterval
+	for i := 1; i < attempts && d < r.opts.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, r.opts.MaxBackoff)
+}
+
+// Cleanup deletes rows published longer than Retention ago.
+func (r *Relay) Cleanup(ctx context.Context) (int, error) {
+	n, err := r.client.Ent().OutboxMessage.
+		Delete().
+		Where(outboxmessage.PublishedAtLT(time.Now().Add(-r.opts.Retention))).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ent.OutboxMessage.Delete: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,157 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"kabsa/internal/db/dbtest"
+	"kabsa/internal/kafka"
+	"kabsa/internal/logging"
+)
+
+// recordingBus records what it publishes and fails the first attempt of
+// every message type listed in failOnce.
+type recordingBus struct {
+	failOnce map[string]bool
+	sent     []string
+	lastCtx  context.Context
+	lastEnv  kafka.Envelope
+}
+
+func (b *recordingBus) Publish(context.Context, string, string, string, any) error {
+	return errors.New("not used")
+}
+
+func (b *recordingBus) PublishEnvelope(ctx context.Context, _ string, _ string, env kafka.Envelope) error {
+	if b.failOnce[env.Type] {
+		delete(b.failOnce, env.Type)
+		return errors.New("broker down")
+	}
+	b.sent = append(b.sent, env.Type)
+	b.lastCtx, b.lastEnv = ctx, env
+	return nil
+}
+
+func TestBus_WritesInCallerTransaction(t *testing.T) {
+	client := dbtest.NewClient(t)
+	bus := NewBus(client)
+	ctx := context.Background()
+
+	errAbort := errors.New("abort")
+	err := client.WithTx(ctx, func(ctx context.Context) error {
+		if err := bus.Publish(ctx, "users", "1", "RolledBack", nil); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithTx = %v, want errAbort", err)
+	}
+
+	err = client.WithTx(ctx, func(ctx context.Context) error {
+		return bus.Publish(ctx, "users", "1", "Committed", nil)
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+
+	rows, err := client.Ent().OutboxMessage.Query().All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Type != "Committed" {
+		t.Fatalf("outbox rows = %+v, want only the committed message", rows)
+	}
+}
+
+func TestRelay_FailedMessageHoldsBackItsKeyOnly(t *testing.T) {
+	client := dbtest.NewClient(t)
+	bus := NewBus(client)
+	ctx := context.Background()
+
+	for _, m := range []struct{ key, typ string }{{"a", "a1"}, {"b", "b1"}, {"a", "a2"}} {
+		if err := bus.Publish(ctx, "users", m.key, m.typ, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pub := &recordingBus{failOnce: map[string]bool{"a1": true}}
+	// The first retry waits one PollInterval; keep it long so the second
+	// pass reliably runs inside the backoff.
+	relay := NewRelay(client, pub, RelayOptions{
+		PollInterval: time.Hour,
+		BatchSize:    10,
+		MaxBackoff:   time.Hour,
+	}, logging.NewNop())
+
+	if _, err := relay.RelayOnce(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(pub.sent) != "[b1]" {
+		t.Fatalf("first pass sent %v, want [b1]", pub.sent)
+	}
+
+	// Still backing off: nothing for key a yet.
+	if _, err := relay.RelayOnce(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(pub.sent) != "[b1]" {
+		t.Fatalf("second pass sent %v, want [b1]", pub.sent)
+	}
+
+	// Let the backoff expire.
+	if err := client.Ent().OutboxMessage.Update().SetAvailableAt(time.Now()).Exec(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := relay.RelayOnce(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(pub.sent) != "[b1 a1 a2]" {
+		t.Fatalf("after backoff sent %v, want [b1 a1 a2]", pub.sent)
+	}
+}
+
+func TestRelay_PublishesUnderWritersContext(t *testing.T) {
+	client := dbtest.NewClient(t)
+	bus := NewBus(client)
+
+	// Written during a traced request.
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "host/abc-000001")
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(ctx, "PATCH /users/{id}")
+	env, err := kafka.NewEnvelope("UserUpdated", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.CorrelationID = "order-42"
+	if err := bus.PublishEnvelope(ctx, "users", "1", env); err != nil {
+		t.Fatal(err)
+	}
+	span.End()
+
+	pub := &recordingBus{}
+	relay := NewRelay(client, pub, RelayOptions{PollInterval: time.Millisecond, BatchSize: 10, MaxBackoff: time.Hour}, logging.NewNop())
+	if _, err := relay.RelayOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if pub.lastCtx == nil {
+		t.Fatal("nothing relayed")
+	}
+	if got := trace.SpanContextFromContext(pub.lastCtx); got.TraceID() != span.SpanContext().TraceID() ||
+		got.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("relayed under span %v, want the writer's %v", got, span.SpanContext())
+	}
+	if got := middleware.GetReqID(pub.lastCtx); got != "host/abc-000001" {
+		t.Errorf("request ID = %q", got)
+	}
+	if pub.lastEnv.CorrelationID != "order-42" {
+		t.Errorf("CorrelationID = %q, want order-42", pub.lastEnv.CorrelationID)
+	}
+}
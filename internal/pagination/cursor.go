@@ -0,0 +1,76 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorCodec turns keyset positions into opaque, tamper-proof tokens.
+// A token is base64url(json) + "." + base64url(hmac-sha256(json)).
+type CursorCodec struct {
+	secret []byte
+}
+
+// NewCursorCodec creates a codec signing with secret. An empty secret gets a
+// random per-process key, which means cursors don't survive restarts and
+// can't be shared between replicas; set one explicitly in real deployments.
+func NewCursorCodec(secret string) (*CursorCodec, error) {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generate cursor secret: %w", err)
+		}
+	}
+	return &CursorCodec{secret: key}, nil
+}
+
+// Encode serializes and signs v.
+func (c *CursorCodec) Encode(v any) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("marshal cursor: %w", err)
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(c.sign(payload)), nil
+}
+
+// Decode verifies token and unmarshals its payload into v.
+// Any malformed or tampered token yields ErrInvalidCursor.
+func (c *CursorCodec) Decode(token string, v any) error {
+	enc := base64.RawURLEncoding
+
+	p, s, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalidCursor
+	}
+	payload, err := enc.DecodeString(p)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	sig, err := enc.DecodeString(s)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if !hmac.Equal(sig, c.sign(payload)) {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(payload, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func (c *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
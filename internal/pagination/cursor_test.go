@@ -0,0 +1,78 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type position struct {
+	ID    int64  `json:"id"`
+	Value string `json:"v"`
+}
+
+func TestCursorCodec_RoundTrip(t *testing.T) {
+	codec, err := NewCursorCodec("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := position{ID: 42, Value: "jane@example.com"}
+
+	token, err := codec.Encode(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out position
+	if err := codec.Decode(token, &out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if out != in {
+		t.Errorf("got %+v, want %+v", out, in)
+	}
+}
+
+func TestCursorCodec_RejectsForeignTokens(t *testing.T) {
+	codec, err := NewCursorCodec("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewCursorCodec("other secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	random, err := NewCursorCodec("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := codec.Encode(position{ID: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, sig, _ := strings.Cut(token, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"id":1}`))
+
+	tests := []struct {
+		name  string
+		codec *CursorCodec
+		token string
+	}{
+		{name: "wrong key", codec: other, token: token},
+		{name: "random key", codec: random, token: token},
+		{name: "tampered payload", codec: codec, token: forged + "." + sig},
+		{name: "tampered signature", codec: codec, token: payload + "." + base64.RawURLEncoding.EncodeToString([]byte("nope"))},
+		{name: "missing signature", codec: codec, token: payload},
+		{name: "not base64", codec: codec, token: "!!!." + sig},
+		{name: "empty", codec: codec, token: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out position
+			if err := tt.codec.Decode(tt.token, &out); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("err = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
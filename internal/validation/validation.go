@@ -0,0 +1,96 @@
+// Package validation checks structs against their `validate:"..."` tags and
+// reports failures as a domcommon.ValidationError, so rules declared in one
+// layer read the same wherever they are enforced.
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/go-playground/validator/v10/non-standard/validators"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+
+	domcommon "kabsa/internal/domain/common"
+)
+
+var (
+	validate *validator.Validate
+	trans    ut.Translator
+)
+
+func init() {
+	validate = validator.New(validator.WithRequiredStructEnabled())
+
+	// Report fields by their JSON name, which is what the client sent.
+	validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+
+	_ = validate.RegisterValidation("notblank", validators.NotBlank)
+
+	english := en.New()
+	trans, _ = ut.New(english, english).GetTranslator("en")
+	if err := entranslations.RegisterDefaultTranslations(validate, trans); err != nil {
+		panic(err)
+	}
+	registerTranslation("notblank", "{0} must not be blank")
+}
+
+func registerTranslation(tag, text string) {
+	err := validate.RegisterTranslation(tag, trans,
+		func(ut ut.Translator) error {
+			return ut.Add(tag, text, true)
+		},
+		func(ut ut.Translator, fe validator.FieldError) string {
+			t, _ := ut.T(tag, fe.Field())
+			return t
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Struct runs struct validation on v and converts failures into a
+// domcommon.ValidationError with one FieldError per failed rule.
+func Struct(v any) error {
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return domcommon.NewValidation(err.Error())
+	}
+
+	fields := make([]domcommon.FieldError, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		fields = append(fields, domcommon.FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: fe.Translate(trans),
+		})
+	}
+	return domcommon.NewValidation("request validation failed", fields...)
+}
+
+// fieldPath drops the top-level struct name from the namespace,
+// e.g. "CreateUserRequest.address.city" -> "address.city".
+func fieldPath(fe validator.FieldError) string {
+	if _, rest, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return rest
+	}
+	return fe.Field()
+}
@@ -0,0 +1,2 @@
+-- Drop "outbox" table
+DROP TABLE "public"."outbox";
@@ -0,0 +1,21 @@
+-- Create "outbox" table
+CREATE TABLE "public"."outbox" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "message_id" character varying NOT NULL,
+  "topic" character varying NOT NULL,
+  "aggregate_key" character varying NOT NULL,
+  "type" character varying NOT NULL,
+  "payload" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "available_at" timestamptz NOT NULL,
+  "attempts" bigint NOT NULL DEFAULT 0,
+  "last_error" character varying NULL,
+  "published_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "outbox_message_id_key" to table: "outbox"
+CREATE UNIQUE INDEX "outbox_message_id_key" ON "public"."outbox" ("message_id");
+-- Create index "outbox_pending_idx" to table: "outbox"
+CREATE INDEX "outbox_pending_idx" ON "public"."outbox" ("id") WHERE (published_at IS NULL);
+-- Create index "outbox_published_at_idx" to table: "outbox"
+CREATE INDEX "outbox_published_at_idx" ON "public"."outbox" ("published_at");
@@ -0,0 +1,15 @@
+-- Create "audit_log" table
+CREATE TABLE "public"."audit_log" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "actor" character varying NOT NULL,
+  "action" character varying NOT NULL,
+  "entity_type" character varying NOT NULL,
+  "entity_id" bigint NOT NULL,
+  "changes" jsonb NOT NULL,
+  "request_id" character varying NULL,
+  "trace_id" character varying NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "audit_log_entity_idx" to table: "audit_log"
+CREATE INDEX "audit_log_entity_idx" ON "public"."audit_log" ("entity_type", "entity_id", "id");
@@ -0,0 +1,2 @@
+-- Drop "inbox" table
+DROP TABLE "public"."inbox";
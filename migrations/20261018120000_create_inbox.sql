@@ -0,0 +1,12 @@
+-- Create "inbox" table
+CREATE TABLE "public"."inbox" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "consumer_group" character varying NOT NULL,
+  "message_id" character varying NOT NULL,
+  "processed_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "inbox_group_message_key" to table: "inbox"
+CREATE UNIQUE INDEX "inbox_group_message_key" ON "public"."inbox" ("consumer_group", "message_id");
+-- Create index "inbox_processed_at_idx" to table: "inbox"
+CREATE INDEX "inbox_processed_at_idx" ON "public"."inbox" ("processed_at");
@@ -0,0 +1,2 @@
+-- Modify "outbox" table
+ALTER TABLE "public"."outbox" DROP COLUMN "headers";
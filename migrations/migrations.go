@@ -0,0 +1,12 @@
+// Package migrations embeds the versioned SQL migrations applied by
+// `kabsa-api migrate`.
+//
+// Files are named <version>_<name>.sql (up) with an optional
+// <version>_<name>.down.sql that reverts it. Versions are timestamps and
+// apply in ascending order; never edit a file once it has been applied.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS